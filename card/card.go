@@ -4,8 +4,6 @@ import (
 	"github.com/google/uuid"
 )
 
-type Legalities map[string]string
-
 type CoreFields struct {
 	// This card’s Arena ID, if any. A large percentage of cards are not available on Arena and do not have this ID.
 	//
@@ -174,6 +172,11 @@ type Card struct {
 	// True if this card is on the Reserved List.
 	Reserved bool `json:"reserved"`
 
+	// The official rulings of this card, oldest first. Rulings are not part of scryfall's card objects, they are attached from the rulings bulk data file by Rulings.Attach.
+	//
+	// This value may be nil
+	Rulings []Ruling `json:"rulings,omitempty"`
+
 	// This card’s toughness, if any. Note that some cards have toughnesses that are not numeric, such as *.
 	//
 	// This value may be nil
@@ -0,0 +1,102 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/goccy/go-json"
+)
+
+var ErrExpectedArray = errors.New("expected a JSON array of cards")
+
+// Decoder reads cards one at a time from a JSON array such as a scryfall bulk data file,
+// so that only a single card has to be held in memory while decoding
+type Decoder struct {
+	dec     *json.Decoder
+	started bool
+	done    bool
+	// index is the position of the next card in the array
+	index int
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next card into c. It returns io.EOF after the last card
+func (d *Decoder) Decode(c *Card) error {
+	if d.done {
+		return io.EOF
+	}
+	if !d.started {
+		t, err := d.dec.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrExpectedArray, err)
+		}
+		if t != json.Delim('[') {
+			return fmt.Errorf("%w: found %v", ErrExpectedArray, t)
+		}
+		d.started = true
+	}
+	if !d.dec.More() {
+		if _, err := d.dec.Token(); err != nil {
+			return fmt.Errorf("%w: %w", ErrExpectedArray, err)
+		}
+		d.done = true
+		return io.EOF
+	}
+	*c = Card{}
+	if err := d.dec.Decode(c); err != nil {
+		return fmt.Errorf("failed to decode card %d: %w", d.index, err)
+	}
+	d.index++
+	return nil
+}
+
+// Each calls f with every remaining card, stopping at the first error from decoding or f.
+// The card passed to f is reused, so f must copy it to keep it
+func (d *Decoder) Each(f func(c *Card) error) error {
+	var c Card
+	for {
+		err := d.Decode(&c)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(&c); err != nil {
+			return err
+		}
+	}
+}
+
+// All returns an iterator over the remaining cards. A decoding error is yielded once and ends the iteration
+func (d *Decoder) All() iter.Seq2[Card, error] {
+	return func(yield func(Card, error) bool) {
+		for {
+			var c Card
+			err := d.Decode(&c)
+			if err == io.EOF {
+				return
+			}
+			if !yield(c, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// DecodeCards reads every card from r, keeping those keep returns true for. A nil keep keeps every card
+func DecodeCards(r io.Reader, keep func(c *Card) bool) ([]Card, error) {
+	var cards []Card
+	err := NewDecoder(r).Each(func(c *Card) error {
+		if keep == nil || keep(c) {
+			cards = append(cards, *c)
+		}
+		return nil
+	})
+	return cards, err
+}
@@ -0,0 +1,98 @@
+package card_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"mtgBuilder/card"
+)
+
+// fixtureArray returns the committed test cards as a JSON array
+func fixtureArray(t *testing.T) []byte {
+	var cards [][]byte
+	for _, file := range []string{"double_faced.json", "flip.json", "mdf.json", "nissa.json", "reversible.json", "split.json"} {
+		cards = append(cards, readFileHelper(t, "testdata/"+file))
+	}
+	return slices.Concat([]byte("["), bytes.Join(cards, []byte(",")), []byte("]"))
+}
+
+func TestDecoder(t *testing.T) {
+	content := fixtureArray(t)
+	var expected []card.Card
+	if err := json.Unmarshal(content, &expected); err != nil {
+		t.Fatalf("failed to unmarshal all cards: %s", err)
+	}
+
+	i := 0
+	for c, err := range card.NewDecoder(bytes.NewReader(content)).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != expected[i].ID || c.Name != expected[i].Name {
+			t.Fatalf("card %d: expected %s, got %s", i, expected[i].Name, c.Name)
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Errorf("expected %d cards, decoded %d", len(expected), i)
+	}
+}
+
+func TestDecodeCards(t *testing.T) {
+	nissa := string(readFileHelper(t, "testdata/nissa.json"))
+	split := string(readFileHelper(t, "testdata/split.json"))
+
+	cards, err := card.DecodeCards(strings.NewReader("["+nissa+","+split+"]"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 || cards[0].Name != "Nissa, Worldsoul Speaker" || cards[1].Name == cards[0].Name {
+		t.Errorf("unexpected cards %v", cards)
+	}
+
+	cards, err = card.DecodeCards(strings.NewReader("["+nissa+","+split+"]"), func(c *card.Card) bool { return len(c.CardFaces) > 0 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 || len(cards[0].CardFaces) == 0 {
+		t.Errorf("expected only the split card, got %v", cards)
+	}
+
+	cards, err = card.DecodeCards(strings.NewReader(" [ ] "), nil)
+	if err != nil || len(cards) != 0 {
+		t.Errorf("expected no cards, got %v, %v", cards, err)
+	}
+
+	if _, err := card.DecodeCards(strings.NewReader(nissa), nil); !errors.Is(err, card.ErrExpectedArray) {
+		t.Errorf("expected %v, got %v", card.ErrExpectedArray, err)
+	}
+	if _, err := card.DecodeCards(strings.NewReader("["+nissa+`,{"name": 1}]`), nil); err == nil {
+		t.Error("expected an error decoding an invalid card")
+	}
+}
+
+func TestDecoderStop(t *testing.T) {
+	d := card.NewDecoder(bytes.NewReader(fixtureArray(t)))
+	n := 0
+	for _, err := range d.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	var c card.Card
+	if err := d.Decode(&c); err != nil {
+		t.Fatalf("failed to resume decoding: %s", err)
+	}
+	if n != 3 || c.Name == "" {
+		t.Errorf("expected to resume after 3 cards, got %d and %+v", n, c.Name)
+	}
+}
@@ -0,0 +1,10 @@
+package card
+
+import "slices"
+
+// UnregisterFormat removes a format added by RegisterFormat, so that tests registering formats don't affect each other
+func UnregisterFormat(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats = slices.DeleteFunc(formats, func(info FormatInfo) bool { return info.Format == f })
+}
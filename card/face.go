@@ -0,0 +1,131 @@
+package card
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Face is a normalized view of one face of a card.
+//
+// Single faced cards have a single face made of the card's own fields. Faces of multifaced cards fall back to the
+// card's fields where a layout keeps them on the card, such as the colors and images of split, flip and adventure cards.
+// Power, toughness, loyalty and defense never fall back, since they belong to a single face of those layouts
+type Face struct {
+	Name       string
+	ManaCost   string
+	TypeLine   string
+	OracleText string
+	// Colors may be nil if neither the face nor the card has colors
+	Colors    *Colors
+	Power     *string
+	Toughness *string
+	Loyalty   *string
+	Defense   *string
+	ImageUris map[string]string
+
+	Cmc        *float32
+	OracleID   *uuid.UUID
+	Artist     *string
+	FlavorText *string
+	Watermark  *string
+
+	// The localized fields of the printing, nil for english printings
+	PrintedName     *string
+	PrintedText     *string
+	PrintedTypeLine *string
+}
+
+// Faces returns the faces of c in printed order. There is always at least one face
+func (c *Card) Faces() []Face {
+	if len(c.CardFaces) == 0 {
+		f := Face{
+			Name:            c.Name,
+			TypeLine:        c.TypeLine,
+			Colors:          c.Colors,
+			Power:           c.Power,
+			Toughness:       c.Toughness,
+			Loyalty:         c.Loyalty,
+			Defense:         c.Defense,
+			ImageUris:       c.ImageUris,
+			Cmc:             c.Cmc,
+			OracleID:        c.OracleID,
+			Artist:          c.Artist,
+			FlavorText:      c.FlavorText,
+			Watermark:       c.Watermark,
+			PrintedName:     c.PrintedName,
+			PrintedText:     c.PrintedText,
+			PrintedTypeLine: c.PrintedTypeLine,
+		}
+		if c.ManaCost != nil {
+			f.ManaCost = *c.ManaCost
+		}
+		if c.OracleText != nil {
+			f.OracleText = *c.OracleText
+		}
+		return []Face{f}
+	}
+
+	faces := make([]Face, len(c.CardFaces))
+	for i, face := range c.CardFaces {
+		f := Face{
+			Name:            face.Name,
+			ManaCost:        face.ManaCost,
+			TypeLine:        facePart(c.TypeLine, i, len(c.CardFaces)),
+			Colors:          fallback(face.Colors, c.Colors),
+			Power:           face.Power,
+			Toughness:       face.Toughness,
+			Loyalty:         face.Loyalty,
+			Defense:         face.Defense,
+			ImageUris:       face.ImageUris,
+			Cmc:             faceManaValue(face, c),
+			OracleID:        fallback(face.OracleID, c.OracleID),
+			Artist:          fallback(face.Artist, c.Artist),
+			FlavorText:      fallback(face.FlavorText, c.FlavorText),
+			Watermark:       fallback(face.Watermark, c.Watermark),
+			PrintedName:     face.PrintedName,
+			PrintedText:     face.PrintedText,
+			PrintedTypeLine: face.PrintedTypeLine,
+		}
+		if face.TypeLine != nil {
+			f.TypeLine = *face.TypeLine
+		}
+		if face.OracleText != nil {
+			f.OracleText = *face.OracleText
+		}
+		if f.ImageUris == nil {
+			f.ImageUris = c.ImageUris
+		}
+		faces[i] = f
+	}
+	return faces
+}
+
+// faceManaValue returns the mana value of a face of c, which comes from the face's own mana cost.
+// Faces without a mana cost, such as the back of a transforming card, have the mana value of the card, see rule 712.8e
+func faceManaValue(face CardFace, c *Card) *float32 {
+	if face.Cmc != nil {
+		return face.Cmc
+	}
+	if m, err := ParseManaCost(face.ManaCost); err == nil && len(m) > 0 {
+		v := m.Value()
+		return &v
+	}
+	return c.Cmc
+}
+
+func fallback[T any](face, card *T) *T {
+	if face != nil {
+		return face
+	}
+	return card
+}
+
+// facePart returns part i of a '//' separated card field with n parts, or the whole field if it has a different number of parts
+func facePart(field string, i, n int) string {
+	parts := strings.Split(field, "//")
+	if len(parts) != n {
+		return field
+	}
+	return strings.TrimSpace(parts[i])
+}
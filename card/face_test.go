@@ -0,0 +1,90 @@
+package card_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"mtgBuilder/card"
+)
+
+func loadCardHelper(t testing.TB, file string) card.Card {
+	t.Helper()
+	var c card.Card
+	if err := json.Unmarshal(readFileHelper(t, file), &c); err != nil {
+		t.Fatalf("failed to unmarshal %s: %s", file, err)
+	}
+	return c
+}
+
+func TestFaces(t *testing.T) {
+	t.Run("split", func(t *testing.T) {
+		c := loadCardHelper(t, "testdata/split.json")
+		faces := c.Faces()
+		if len(faces) != 2 {
+			t.Fatalf("expected 2 faces, got %d", len(faces))
+		}
+		for _, f := range faces {
+			if f.Colors == nil || len(*f.Colors) != 2 {
+				t.Errorf("%s: expected the card colors, got %v", f.Name, f.Colors)
+			}
+			if f.ImageUris == nil {
+				t.Errorf("%s: expected the card images", f.Name)
+			}
+			if f.TypeLine != "Instant" {
+				t.Errorf("%s: expected type line Instant, got %q", f.Name, f.TypeLine)
+			}
+		}
+		if faces[0].Cmc == nil || *faces[0].Cmc != 2 || faces[1].Cmc == nil || *faces[1].Cmc != 1 {
+			t.Errorf("expected each face to have the mana value of its own cost, got %v and %v", faces[0].Cmc, faces[1].Cmc)
+		}
+	})
+
+	t.Run("flip", func(t *testing.T) {
+		c := loadCardHelper(t, "testdata/flip.json")
+		faces := c.Faces()
+		if faces[0].Power == nil || *faces[0].Power != "1" {
+			t.Errorf("expected the first face to have power 1, got %v", faces[0].Power)
+		}
+		if faces[1].Power != nil {
+			t.Errorf("expected the second face to have no power, got %s", *faces[1].Power)
+		}
+		if faces[1].TypeLine != "Legendary Enchantment" {
+			t.Errorf("expected the second face to be a Legendary Enchantment, got %q", faces[1].TypeLine)
+		}
+	})
+
+	t.Run("modal_dfc", func(t *testing.T) {
+		c := loadCardHelper(t, "testdata/mdf.json")
+		faces := c.Faces()
+		if faces[1].Colors == nil || !slices.Contains(*faces[1].Colors, "R") {
+			t.Errorf("expected the second face to keep its own colors, got %v", faces[1].Colors)
+		}
+		if faces[0].ImageUris == nil {
+			t.Error("expected the first face to keep its own images")
+		}
+		if faces[1].Cmc == nil || *faces[1].Cmc != 8 {
+			t.Errorf("expected the second face to have mana value 8, got %v", faces[1].Cmc)
+		}
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		c := loadCardHelper(t, "testdata/double_faced.json")
+		faces := c.Faces()
+		if faces[1].Cmc == nil || *faces[1].Cmc != *c.Cmc {
+			t.Errorf("expected the back face to have the mana value of the card, got %v", faces[1].Cmc)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		c := loadCardHelper(t, "testdata/nissa.json")
+		faces := c.Faces()
+		if len(faces) != 1 {
+			t.Fatalf("expected 1 face, got %d", len(faces))
+		}
+		if faces[0].Name != c.Name || faces[0].TypeLine != c.TypeLine || faces[0].OracleText != *c.OracleText {
+			t.Errorf("expected the face to be made of the card fields, got %+v", faces[0])
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package card
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Format is a play format as named in Legalities, ex. standard
+type Format string
+
+// LegalityStatus is the legality of a card in a format
+type LegalityStatus string
+
+const (
+	Legal      LegalityStatus = "legal"
+	NotLegal   LegalityStatus = "not_legal"
+	Restricted LegalityStatus = "restricted"
+	Banned     LegalityStatus = "banned"
+)
+
+// Valid reports whether s is one of the statuses used by scryfall
+func (s LegalityStatus) Valid() bool {
+	switch s {
+	case Legal, NotLegal, Restricted, Banned:
+		return true
+	}
+	return false
+}
+
+// Legalities are the legality of a card in each format
+type Legalities map[Format]LegalityStatus
+
+// Status returns the legality of the card in f. Cards are not legal in formats they have no legality for
+func (l Legalities) Status(f Format) LegalityStatus {
+	if s, exists := l[f]; exists {
+		return s
+	}
+	return NotLegal
+}
+
+// FormatInfo describes a known format
+type FormatInfo struct {
+	Format Format
+	// Name is the display name of the format, ex. Pauper Commander
+	Name string
+}
+
+// formats are the known formats in the order scryfall lists them
+var formats = []FormatInfo{
+	{"standard", "Standard"},
+	{"future", "Future"},
+	{"historic", "Historic"},
+	{"timeless", "Timeless"},
+	{"gladiator", "Gladiator"},
+	{"pioneer", "Pioneer"},
+	{"modern", "Modern"},
+	{"legacy", "Legacy"},
+	{"pauper", "Pauper"},
+	{"vintage", "Vintage"},
+	{"penny", "Penny Dreadful"},
+	{"commander", "Commander"},
+	{"oathbreaker", "Oathbreaker"},
+	{"standardbrawl", "Standard Brawl"},
+	{"brawl", "Brawl"},
+	{"alchemy", "Alchemy"},
+	{"paupercommander", "Pauper Commander"},
+	{"duel", "Duel Commander"},
+	{"oldschool", "Old School"},
+	{"premodern", "Premodern"},
+	{"predh", "PreDH"},
+}
+
+// formatsMu guards formats, which may be registered while queries are parsed
+var formatsMu sync.RWMutex
+
+// Formats returns the known formats
+func Formats() []FormatInfo {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	return slices.Clone(formats)
+}
+
+// RegisterFormat adds a format, or renames a known format, so that new scryfall formats can be used before the model is updated
+func RegisterFormat(f Format, name string) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if i := slices.IndexFunc(formats, func(info FormatInfo) bool { return info.Format == f }); i != -1 {
+		formats[i].Name = name
+		return
+	}
+	formats = append(formats, FormatInfo{f, name})
+}
+
+// LookupFormat finds a known format by its format or display name, ignoring case and spaces
+func LookupFormat(name string) (FormatInfo, bool) {
+	name = strings.ReplaceAll(name, " ", "")
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	i := slices.IndexFunc(formats, func(info FormatInfo) bool {
+		return strings.EqualFold(string(info.Format), name) || strings.EqualFold(strings.ReplaceAll(info.Name, " ", ""), name)
+	})
+	if i == -1 {
+		return FormatInfo{}, false
+	}
+	return formats[i], true
+}
+
+// DisplayName returns the display name of f, or f itself if it is not a known format
+func (f Format) DisplayName() string {
+	if info, exists := LookupFormat(string(f)); exists {
+		return info.Name
+	}
+	return string(f)
+}
@@ -0,0 +1,41 @@
+package card_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+)
+
+func TestLookupFormat(t *testing.T) {
+	cases := map[string]card.Format{
+		"modern":           "modern",
+		"Pauper Commander": "paupercommander",
+		"duelcommander":    "duel",
+		"PREDH":            "predh",
+	}
+	for name, expected := range cases {
+		info, ok := card.LookupFormat(name)
+		if !ok || info.Format != expected {
+			t.Errorf("%s: expected %s, got %+v", name, expected, info)
+		}
+	}
+	if _, ok := card.LookupFormat("comander"); ok {
+		t.Error("expected comander to be unknown")
+	}
+
+	card.RegisterFormat("testformat", "Test Format")
+	t.Cleanup(func() { card.UnregisterFormat("testformat") })
+	if got := card.Format("testformat").DisplayName(); got != "Test Format" {
+		t.Errorf("expected a registered format to have its display name, got %s", got)
+	}
+}
+
+func TestLegalitiesStatus(t *testing.T) {
+	l := card.Legalities{"modern": card.Banned}
+	if l.Status("modern") != card.Banned || l.Status("legacy") != card.NotLegal {
+		t.Errorf("unexpected statuses %s, %s", l.Status("modern"), l.Status("legacy"))
+	}
+	if !card.Restricted.Valid() || card.LegalityStatus("legl").Valid() {
+		t.Error("expected only scryfall statuses to be valid")
+	}
+}
@@ -0,0 +1,138 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ManaCost is a parsed mana cost. Each symbol is stored without braces, such as "2", "G", "W/U" or "B/P"
+type ManaCost []string
+
+var ErrInvalidManaCost = errors.New("invalid mana cost")
+
+// ParseManaCost parses a mana cost of braced symbols, ex. {2}{G}{G}
+func ParseManaCost(cost string) (ManaCost, error) {
+	m := ManaCost{}
+	rest := strings.TrimSpace(cost)
+	for rest != "" {
+		if rest[0] != '{' {
+			return nil, fmt.Errorf("%w: expected '{' in %q", ErrInvalidManaCost, cost)
+		}
+		end := strings.IndexByte(rest, '}')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: missing '}' in %q", ErrInvalidManaCost, cost)
+		}
+		symbol := strings.ToUpper(rest[1:end])
+		if symbol == "" {
+			return nil, fmt.Errorf("%w: empty symbol in %q", ErrInvalidManaCost, cost)
+		}
+		m = append(m, normalizeSymbol(symbol))
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	return m, nil
+}
+
+// colorOrder is the order of the colors around the color pie
+var colorOrder = []string{"W", "U", "B", "R", "G"}
+
+// normalizeSymbol orders the parts of hybrid and Phyrexian symbols the way they are printed, so that {G/W} and {W/G} are the same symbol.
+// Generic mana comes first and P last, a pair of colors is in WUBRG order going clockwise around the color pie, ex. W/U, W/B, R/W and G/U
+func normalizeSymbol(symbol string) string {
+	parts := strings.Split(symbol, "/")
+	if len(parts) < 2 {
+		return symbol
+	}
+	var generic, colors []string
+	phyrexian := false
+	for _, part := range parts {
+		switch {
+		case part == "P":
+			phyrexian = true
+		case slices.Contains(colorOrder, part):
+			colors = append(colors, part)
+		default:
+			generic = append(generic, part)
+		}
+	}
+	if len(colors) == 2 {
+		// the second color is one or two steps clockwise from the first
+		if steps := (slices.Index(colorOrder, colors[1]) - slices.Index(colorOrder, colors[0]) + len(colorOrder)) % len(colorOrder); steps > 2 {
+			colors[0], colors[1] = colors[1], colors[0]
+		}
+	}
+	parts = append(generic, colors...)
+	if phyrexian {
+		parts = append(parts, "P")
+	}
+	return strings.Join(parts, "/")
+}
+
+// SymbolColors returns the colors of a mana symbol. Hybrid symbols have each of their colors
+func SymbolColors(symbol string) Colors {
+	var colors Colors
+	for _, part := range strings.Split(symbol, "/") {
+		switch part {
+		case "W", "U", "B", "R", "G":
+			colors.Add(Colors{part})
+		}
+	}
+	return colors
+}
+
+// Pips returns the number of colored symbols in m
+func (m ManaCost) Pips() int {
+	var n int
+	for _, s := range m {
+		if len(SymbolColors(s)) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Devotion returns the number of symbols in m that are at least one of colors, see rule 700.5
+func (m ManaCost) Devotion(colors Colors) int {
+	var n int
+	for _, s := range m {
+		if slices.ContainsFunc(SymbolColors(s), func(c string) bool { return slices.Contains(colors, c) }) {
+			n++
+		}
+	}
+	return n
+}
+
+// Value returns the mana value of m, see rule 202.3. X counts as 0, half mana as ½
+// and a hybrid symbol as its largest part, so {2/W} counts as 2
+func (m ManaCost) Value() float32 {
+	var v float32
+	for _, s := range m {
+		v += symbolValue(s)
+	}
+	return v
+}
+
+// symbolValue returns the mana value of a single symbol
+func symbolValue(symbol string) float32 {
+	if symbol == "1/2" {
+		return 0.5
+	}
+	var v float32
+	for _, part := range strings.Split(symbol, "/") {
+		var pv float32
+		switch {
+		case part == "X" || part == "Y" || part == "Z":
+		case part == "½" || strings.HasPrefix(part, "H"):
+			pv = 0.5
+		default:
+			pv = 1
+			if n, err := strconv.ParseFloat(part, 32); err == nil {
+				pv = float32(n)
+			}
+		}
+		v = max(v, pv)
+	}
+	return v
+}
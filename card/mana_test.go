@@ -0,0 +1,81 @@
+package card_test
+
+import (
+	"errors"
+	"testing"
+
+	"mtgBuilder/card"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseManaCost(t *testing.T) {
+	cases := map[string]card.ManaCost{
+		"":                     {},
+		"{2}{G}{G}":            {"2", "G", "G"},
+		"{X}{w/u}{2/B}{G/P}":   {"X", "W/U", "2/B", "G/P"},
+		"{10}{C} ":             {"10", "C"},
+		"{B/G/P}{S}{H/R}{1/2}": {"B/G/P", "S", "H/R", "1/2"},
+		"{W/G}{G/W}{U/W}{B/W}": {"G/W", "G/W", "W/U", "W/B"},
+		"{P/W/G}{B/2}{g/u}":    {"G/W/P", "2/B", "G/U"},
+	}
+	for cost, want := range cases {
+		got, err := card.ParseManaCost(cost)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %s", cost, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mana cost %q mismatch (-want +got):\n%s", cost, diff)
+		}
+	}
+
+	for _, cost := range []string{"2G", "{2}{G", "{}", "{1} // {G}"} {
+		if _, err := card.ParseManaCost(cost); !errors.Is(err, card.ErrInvalidManaCost) {
+			t.Errorf("expected %s parsing %q, got %v", card.ErrInvalidManaCost, cost, err)
+		}
+	}
+}
+
+func TestDevotion(t *testing.T) {
+	m, err := card.ParseManaCost("{1}{B}{B}{B/G}{G/P}{C}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Pips(); got != 4 {
+		t.Errorf("expected 4 pips, got %d", got)
+	}
+	cases := []struct {
+		colors card.Colors
+		want   int
+	}{
+		{card.Colors{"B"}, 3},
+		{card.Colors{"G"}, 2},
+		{card.Colors{"B", "G"}, 4},
+		{card.Colors{"W"}, 0},
+	}
+	for _, c := range cases {
+		if got := m.Devotion(c.colors); got != c.want {
+			t.Errorf("expected devotion to %v of %d, got %d", c.colors, c.want, got)
+		}
+	}
+}
+
+func TestManaValue(t *testing.T) {
+	cases := map[string]float32{
+		"":                0,
+		"{2}{G}{G}":       4,
+		"{X}{X}{R}":       1,
+		"{2/W}{2/W}{G/P}": 5,
+		"{HW}{1/2}{10}":   11,
+		"{W/U}{B/G/P}{C}": 3,
+	}
+	for cost, want := range cases {
+		m, err := card.ParseManaCost(cost)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %s", cost, err)
+		}
+		if got := m.Value(); got != want {
+			t.Errorf("expected mana value %v for %q, got %v", want, cost, got)
+		}
+	}
+}
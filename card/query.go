@@ -1,14 +1,10 @@
 package card
 
+// GetOracleText returns the oracle text of every face of c
 func (c *Card) GetOracleText() []string {
 	var o []string
-	if c.OracleText != nil {
-		o = append(o, *c.OracleText)
-	}
-	for _, face := range c.CardFaces {
-		if face.OracleText != nil {
-			o = append(o, *face.OracleText)
-		}
+	for _, face := range c.Faces() {
+		o = append(o, face.OracleText)
 	}
 	return o
 }
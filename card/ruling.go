@@ -0,0 +1,82 @@
+package card
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type Ruling struct {
+	// A content type for this object, always ruling.
+	Object string `json:"object"`
+
+	// The oracle ID of the card this ruling is for.
+	OracleID uuid.UUID `json:"oracle_id"`
+
+	// A computer-readable string indicating which company produced this ruling, either wotc or scryfall.
+	Source string `json:"source"`
+
+	// The date when the ruling or note was published, in YYYY-MM-DD format.
+	PublishedAt string `json:"published_at"`
+
+	// The text of the ruling.
+	Comment string `json:"comment"`
+}
+
+// Rulings are the rulings of each card by oracle ID
+type Rulings map[uuid.UUID][]Ruling
+
+// DecodeRulings reads a JSON array of rulings such as scryfall's rulings bulk data file.
+// The rulings of each card are sorted oldest first
+func DecodeRulings(r io.Reader) (Rulings, error) {
+	dec := json.NewDecoder(r)
+	t, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("expected a JSON array of rulings: %w", err)
+	}
+	if t != json.Delim('[') {
+		return nil, fmt.Errorf("expected a JSON array of rulings, found %v", t)
+	}
+
+	rulings := Rulings{}
+	for i := 0; dec.More(); i++ {
+		var ruling Ruling
+		if err := dec.Decode(&ruling); err != nil {
+			return nil, fmt.Errorf("failed to decode ruling %d: %w", i, err)
+		}
+		rulings[ruling.OracleID] = append(rulings[ruling.OracleID], ruling)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of rulings: %w", err)
+	}
+
+	for _, rs := range rulings {
+		slices.SortStableFunc(rs, func(a, b Ruling) int { return cmp.Compare(a.PublishedAt, b.PublishedAt) })
+	}
+	return rulings, nil
+}
+
+// OracleIDs returns the distinct oracle IDs of c and its faces, since reversible cards only have oracle IDs on their faces
+func (c *Card) OracleIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, f := range c.Faces() {
+		if f.OracleID != nil && !slices.Contains(ids, *f.OracleID) {
+			ids = append(ids, *f.OracleID)
+		}
+	}
+	return ids
+}
+
+// Attach sets the Rulings of every card from r, replacing any rulings the cards already had
+func (r Rulings) Attach(cards []Card) {
+	for i := range cards {
+		cards[i].Rulings = nil
+		for _, id := range cards[i].OracleIDs() {
+			cards[i].Rulings = append(cards[i].Rulings, r[id]...)
+		}
+	}
+}
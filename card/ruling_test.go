@@ -0,0 +1,43 @@
+package card_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+)
+
+func TestRulings(t *testing.T) {
+	rulings, err := card.DecodeRulings(bytes.NewReader(readFileHelper(t, "testdata/rulings.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rulings) != 3 {
+		t.Fatalf("expected rulings for 3 cards, got %d", len(rulings))
+	}
+
+	split := rulings[uuid.MustParse("9842734c-1eac-4509-a731-4c22017ae586")]
+	if len(split) != 2 || split[0].PublishedAt != "2006-05-01" {
+		t.Errorf("expected 2 rulings oldest first, got %+v", split)
+	}
+
+	cards := []card.Card{
+		loadCardHelper(t, "testdata/split.json"),
+		loadCardHelper(t, "testdata/reversible.json"),
+		loadCardHelper(t, "testdata/nissa.json"),
+	}
+	rulings.Attach(cards)
+	for i, expected := range []int{2, 1, 0} {
+		if got := len(cards[i].Rulings); got != expected {
+			t.Errorf("%s: expected %d rulings, got %d", cards[i].Name, expected, got)
+		}
+	}
+}
+
+func TestDecodeRulingsInvalid(t *testing.T) {
+	if _, err := card.DecodeRulings(bytes.NewReader([]byte(`{"object": "ruling"}`))); err == nil {
+		t.Error("expected an error decoding an object")
+	}
+}
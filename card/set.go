@@ -0,0 +1,90 @@
+package card
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type Set struct {
+	// A content type for this object, always set.
+	Object string `json:"object"`
+
+	// A unique ID for this set on Scryfall that will not change.
+	ID uuid.UUID `json:"id"`
+
+	// The unique three to six-letter code for this set.
+	Code string `json:"code"`
+
+	// The English name of the set.
+	Name string `json:"name"`
+
+	// A computer-readable classification for this set.
+	SetType string `json:"set_type"`
+
+	// The date the set was released or the first card was printed in the set (in GMT-8 Pacific time).
+	//
+	// This value may be empty
+	ReleasedAt string `json:"released_at,omitempty"`
+
+	// The block code for this set, if any.
+	//
+	// This value may be nil
+	BlockCode *string `json:"block_code,omitempty"`
+
+	// The block or group name code for this set, if any.
+	//
+	// This value may be nil
+	Block *string `json:"block,omitempty"`
+
+	// The set code for the parent set, if any. promo and token sets often have a parent set.
+	//
+	// This value may be nil
+	ParentSetCode *string `json:"parent_set_code,omitempty"`
+
+	// The number of cards in this set.
+	CardCount int `json:"card_count"`
+
+	// True if this set was only released in a video game.
+	Digital bool `json:"digital"`
+}
+
+// releaseDateLayout is the layout of ReleasedAt
+const releaseDateLayout = "2006-01-02"
+
+// Released returns the release date of s, if known
+func (s Set) Released() (time.Time, bool) {
+	t, err := time.Parse(releaseDateLayout, s.ReleasedAt)
+	return t, err == nil
+}
+
+// setList is the list object returned by scryfall's sets endpoint
+type setList struct {
+	Data []Set `json:"data"`
+}
+
+// DecodeSets reads sets from either the list object returned by scryfall's sets endpoint or a JSON array of sets
+func DecodeSets(r io.Reader) ([]Set, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content = bytes.TrimSpace(content)
+
+	var sets []Set
+	if len(content) > 0 && content[0] == '{' {
+		var list setList
+		err = json.Unmarshal(content, &list)
+		sets = list.Data
+	} else {
+		err = json.Unmarshal(content, &sets)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sets: %w", err)
+	}
+	return sets, nil
+}
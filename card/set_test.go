@@ -0,0 +1,36 @@
+package card_test
+
+import (
+	"bytes"
+	"testing"
+
+	"mtgBuilder/card"
+)
+
+func TestDecodeSets(t *testing.T) {
+	list := readFileHelper(t, "testdata/sets.json")
+	sets, err := card.DecodeSets(bytes.NewReader(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 5 {
+		t.Fatalf("expected 5 sets, got %d", len(sets))
+	}
+	if sets[1].Code != "sok" || sets[1].BlockCode == nil || *sets[1].BlockCode != "chk" || sets[1].CardCount != 165 {
+		t.Errorf("unexpected set %+v", sets[1])
+	}
+	if sets[4].ParentSetCode == nil || *sets[4].ParentSetCode != "stx" {
+		t.Errorf("expected pstx to have the parent stx, got %+v", sets[4])
+	}
+	if released, ok := sets[0].Released(); !ok || released.Year() != 2004 {
+		t.Errorf("expected chk to be released in 2004, got %s", released)
+	}
+
+	array, err := card.DecodeSets(bytes.NewReader([]byte(`[{"code": "cmr", "name": "Commander Legends"}]`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(array) != 1 || array[0].Code != "cmr" {
+		t.Errorf("expected to decode an array of sets, got %+v", array)
+	}
+}
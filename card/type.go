@@ -0,0 +1,72 @@
+package card
+
+import (
+	"slices"
+	"strings"
+)
+
+// TypeLine is a type line split into its supertypes, card types and subtypes
+type TypeLine struct {
+	Supertypes []string `json:"supertypes,omitempty"`
+	Types      []string `json:"types,omitempty"`
+	Subtypes   []string `json:"subtypes,omitempty"`
+}
+
+// Supertypes lists the known supertypes. Token is not a supertype by the rules but is printed as one
+var Supertypes = []string{"Basic", "Elite", "Host", "Legendary", "Ongoing", "Snow", "Token", "World"}
+
+// ParseTypeLine parses a type line, returning one TypeLine per '//' separated face. Words before the dash that aren't
+// Supertypes are card types, so new card types are parsed without being listed
+func ParseTypeLine(line string) []TypeLine {
+	var lines []TypeLine
+	for _, face := range strings.Split(line, "//") {
+		if strings.TrimSpace(face) == "" {
+			continue
+		}
+		lines = append(lines, parseFaceTypeLine(face))
+	}
+	return lines
+}
+
+func parseFaceTypeLine(line string) TypeLine {
+	var t TypeLine
+	types, subtypes, _ := strings.Cut(line, "—")
+	for _, word := range strings.Fields(types) {
+		if slices.Contains(Supertypes, word) {
+			t.Supertypes = append(t.Supertypes, word)
+		} else {
+			t.Types = append(t.Types, word)
+		}
+	}
+	if slices.Contains(t.Types, "Plane") {
+		// planar types may contain spaces, see rule 205.3n
+		if s := strings.TrimSpace(subtypes); s != "" {
+			t.Subtypes = []string{s}
+		}
+		return t
+	}
+	for _, word := range strings.Fields(subtypes) {
+		t.Subtypes = append(t.Subtypes, word)
+	}
+	return t
+}
+
+// TypeLines returns the parsed type line of every face of c
+func (c *Card) TypeLines() []TypeLine {
+	var lines []TypeLine
+	for _, face := range c.Faces() {
+		lines = append(lines, ParseTypeLine(face.TypeLine)...)
+	}
+	return lines
+}
+
+// TypeLineWords returns the lowercased words of a type line, ignoring the '—' and '//' separators
+func TypeLineWords(line string) []string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(line)) {
+		if w != "—" && w != "//" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,37 @@
+package card_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTypeLine(t *testing.T) {
+	cases := map[string][]card.TypeLine{
+		"Legendary Creature — Elf Druid": {
+			{Supertypes: []string{"Legendary"}, Types: []string{"Creature"}, Subtypes: []string{"Elf", "Druid"}},
+		},
+		"Instant // Instant": {
+			{Types: []string{"Instant"}},
+			{Types: []string{"Instant"}},
+		},
+		"Legendary Creature — Moonfolk Monk // Legendary Enchantment": {
+			{Supertypes: []string{"Legendary"}, Types: []string{"Creature"}, Subtypes: []string{"Moonfolk", "Monk"}},
+			{Supertypes: []string{"Legendary"}, Types: []string{"Enchantment"}},
+		},
+		"Basic Snow Land — Forest": {
+			{Supertypes: []string{"Basic", "Snow"}, Types: []string{"Land"}, Subtypes: []string{"Forest"}},
+		},
+		"Plane — Bolas's Meditation Realm": {
+			{Types: []string{"Plane"}, Subtypes: []string{"Bolas's Meditation Realm"}},
+		},
+		"": nil,
+	}
+	for line, want := range cases {
+		if diff := cmp.Diff(want, card.ParseTypeLine(line)); diff != "" {
+			t.Errorf("type line %q mismatch (-want +got):\n%s", line, diff)
+		}
+	}
+}
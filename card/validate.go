@@ -0,0 +1,300 @@
+package card
+
+import (
+	"cmp"
+	"encoding"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// IssueKind is a kind of difference between card JSON and Card
+type IssueKind int
+
+const (
+	// UnknownField is a JSON field without a matching Card field, it is dropped when decoding
+	UnknownField IssueKind = iota
+	// TypeMismatch is a JSON value that can't be decoded into the type of its Card field
+	TypeMismatch
+	// NullViolation is a null JSON value for a Card field that can't be nil
+	NullViolation
+	// MissingField is a required Card field, one that is neither a pointer nor omitempty, without a JSON field
+	MissingField
+)
+
+var issueKindNames = []string{"unknown field", "type mismatch", "null violation", "missing field"}
+
+func (k IssueKind) String() string {
+	if k < 0 || int(k) >= len(issueKindNames) {
+		return fmt.Sprintf("IssueKind(%d)", int(k))
+	}
+	return issueKindNames[k]
+}
+
+func (k IssueKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Issue is a difference between card JSON and Card at a path, ex. card_faces[].mana_cost
+type Issue struct {
+	Kind IssueKind `json:"kind"`
+	Path string    `json:"path"`
+	// Detail describes type mismatches, ex. expected string, found number
+	Detail string `json:"detail,omitempty"`
+}
+
+// IssueCount counts the cards with an Issue
+type IssueCount struct {
+	Issue
+	Count int `json:"count"`
+	// Examples are the IDs of the first cards with the issue
+	Examples []string `json:"examples"`
+}
+
+// maxExamples is the max amount of example card IDs kept per issue
+const maxExamples = 3
+
+// Report is the result of validating card JSON against Card
+type Report struct {
+	Cards  int
+	issues map[Issue]*IssueCount
+}
+
+// Issues returns every issue found, most common first
+func (r *Report) Issues() []IssueCount {
+	issues := make([]IssueCount, 0, len(r.issues))
+	for _, i := range r.issues {
+		issues = append(issues, *i)
+	}
+	slices.SortFunc(issues, func(a, b IssueCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return issues
+}
+
+// add records issue once per card
+func (r *Report) add(issue Issue, id string, seen map[Issue]bool) {
+	if seen[issue] {
+		return
+	}
+	seen[issue] = true
+	if r.issues == nil {
+		r.issues = map[Issue]*IssueCount{}
+	}
+	count, exists := r.issues[issue]
+	if !exists {
+		count = &IssueCount{Issue: issue}
+		r.issues[issue] = count
+	}
+	count.Count++
+	if len(count.Examples) < maxExamples {
+		count.Examples = append(count.Examples, id)
+	}
+}
+
+// Validate strictly checks a JSON array of cards such as a scryfall bulk data file against Card,
+// reporting fields that would be dropped, fail to decode or are missing. Cards are read one at a time
+func Validate(r io.Reader) (Report, error) {
+	var report Report
+	dec := json.NewDecoder(r)
+	t, err := dec.Token()
+	if err != nil {
+		return report, fmt.Errorf("%w: %w", ErrExpectedArray, err)
+	}
+	if t != json.Delim('[') {
+		return report, fmt.Errorf("%w: found %v", ErrExpectedArray, t)
+	}
+
+	cardType := reflect.TypeFor[Card]()
+	for dec.More() {
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return report, fmt.Errorf("failed to decode card %d: %w", report.Cards, err)
+		}
+		v := validator{report: &report, seen: map[Issue]bool{}}
+		if obj, ok := value.(map[string]any); ok {
+			v.id, _ = obj["id"].(string)
+		}
+		if v.id == "" {
+			v.id = fmt.Sprintf("#%d", report.Cards)
+		}
+		v.validate("", cardType, value)
+		report.Cards++
+	}
+	if _, err := dec.Token(); err != nil {
+		return report, fmt.Errorf("%w: %w", ErrExpectedArray, err)
+	}
+	return report, nil
+}
+
+// validator checks a single card
+type validator struct {
+	report *Report
+	id     string
+	seen   map[Issue]bool
+}
+
+func (v validator) add(kind IssueKind, path, detail string) {
+	v.report.add(Issue{kind, path, detail}, v.id, v.seen)
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// validate checks value, decoded from JSON, against t
+func (v validator) validate(path string, t reflect.Type, value any) {
+	if value == nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		default:
+			v.add(NullViolation, path, "")
+		}
+		return
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	ptr := reflect.PointerTo(t)
+	switch {
+	case ptr.Implements(jsonUnmarshaler):
+		return
+	case ptr.Implements(textUnmarshaler):
+		v.expect(path, "string", value)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.mismatch(path, "object", value)
+			return
+		}
+		fields := jsonFields(t)
+		for key, fieldValue := range obj {
+			field, exists := fields[key]
+			if !exists {
+				v.add(UnknownField, joinPath(path, key), "")
+				continue
+			}
+			v.validate(joinPath(path, key), field.Type, fieldValue)
+		}
+		for key, field := range fields {
+			if _, exists := obj[key]; field.Required && !exists {
+				v.add(MissingField, joinPath(path, key), "")
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := value.([]any)
+		if !ok {
+			v.mismatch(path, "array", value)
+			return
+		}
+		for _, elem := range arr {
+			v.validate(path+"[]", t.Elem(), elem)
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.mismatch(path, "object", value)
+			return
+		}
+		for _, elem := range obj {
+			v.validate(path+".*", t.Elem(), elem)
+		}
+	case reflect.String:
+		v.expect(path, "string", value)
+	case reflect.Bool:
+		v.expect(path, "boolean", value)
+	case reflect.Float32, reflect.Float64:
+		v.expect(path, "number", value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			v.mismatch(path, "integer", value)
+		}
+	}
+}
+
+// expect reports a mismatch if value isn't of the JSON type expected
+func (v validator) expect(path, expected string, value any) {
+	if jsonType(value) != expected {
+		v.mismatch(path, expected, value)
+	}
+}
+
+func (v validator) mismatch(path, expected string, value any) {
+	v.add(TypeMismatch, path, fmt.Sprintf("expected %s, found %s", expected, jsonType(value)))
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonField is a field of a struct decoded from JSON
+type jsonField struct {
+	Type reflect.Type
+	// Required is set for fields that are expected in every object, those that are neither pointers nor omitempty
+	Required bool
+}
+
+// jsonFields returns the fields of struct t by JSON name, including the fields of embedded structs
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for name, field := range jsonFields(f.Type) {
+				fields[name] = field
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := slices.Contains(strings.Split(options, ","), "omitempty")
+		fields[name] = jsonField{f.Type, f.Type.Kind() != reflect.Pointer && !omitempty}
+	}
+	return fields
+}
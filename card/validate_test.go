@@ -0,0 +1,85 @@
+package card_test
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/google/go-cmp/cmp"
+
+	"mtgBuilder/card"
+)
+
+func TestValidate(t *testing.T) {
+	f, err := os.Open("testdata/validate.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	report, err := card.Validate(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Cards != 6 || len(report.Issues()) != 0 {
+		t.Errorf("expected 6 cards without issues, got %d cards with %+v", report.Cards, report.Issues())
+	}
+}
+
+func TestValidateDrift(t *testing.T) {
+	cards := `[
+		{"id": "a", "name": "A", "new_field": 1, "cmc": "3", "reserved": null, "card_faces": [{"name": "A1", "face_field": true}]},
+		{"id": "b", "name": "B", "new_field": "x", "multiverse_ids": [1.5], "legalities": {"standard": 1}},
+		{"id": "c", "name": "C", "new_field": null, "oracle_id": 4}
+	]`
+	report, err := card.Validate(strings.NewReader(cards))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the drift cards leave out most required fields, those are checked on a complete card below
+	issues := slices.DeleteFunc(report.Issues(), func(i card.IssueCount) bool { return i.Kind == card.MissingField })
+	expected := []card.IssueCount{
+		{Issue: card.Issue{Kind: card.UnknownField, Path: "new_field"}, Count: 3, Examples: []string{"a", "b", "c"}},
+		{Issue: card.Issue{Kind: card.UnknownField, Path: "card_faces[].face_field"}, Count: 1, Examples: []string{"a"}},
+		{Issue: card.Issue{Kind: card.TypeMismatch, Path: "cmc", Detail: "expected number, found string"}, Count: 1, Examples: []string{"a"}},
+		{Issue: card.Issue{Kind: card.TypeMismatch, Path: "legalities.*", Detail: "expected string, found number"}, Count: 1, Examples: []string{"b"}},
+		{Issue: card.Issue{Kind: card.TypeMismatch, Path: "multiverse_ids[]", Detail: "expected integer, found number"}, Count: 1, Examples: []string{"b"}},
+		{Issue: card.Issue{Kind: card.TypeMismatch, Path: "oracle_id", Detail: "expected string, found number"}, Count: 1, Examples: []string{"c"}},
+		{Issue: card.Issue{Kind: card.NullViolation, Path: "reserved"}, Count: 1, Examples: []string{"a"}},
+	}
+	if diff := cmp.Diff(expected, issues); diff != "" {
+		t.Errorf("unexpected issues (-expected +got):\n%s", diff)
+	}
+
+	content, err := os.ReadFile("testdata/validate.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var complete []map[string]any
+	if err := json.Unmarshal(content, &complete); err != nil {
+		t.Fatal(err)
+	}
+	delete(complete[0], "collector_number")
+	content, err = json.Marshal(complete[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err = card.Validate(bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []card.IssueCount{
+		{Issue: card.Issue{Kind: card.MissingField, Path: "collector_number"}, Count: 1, Examples: []string{complete[0]["id"].(string)}},
+	}
+	if diff := cmp.Diff(expected, report.Issues()); diff != "" {
+		t.Errorf("unexpected issues for a missing field (-expected +got):\n%s", diff)
+	}
+}
+
+func TestValidateNotArray(t *testing.T) {
+	if _, err := card.Validate(bytes.NewReader([]byte(`{"id": "a"}`))); err == nil {
+		t.Error("expected an error validating an object")
+	}
+}
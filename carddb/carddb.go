@@ -0,0 +1,457 @@
+// Package carddb reads and writes a compact binary card database.
+//
+// A database starts with a fixed size header holding the schema version, a fingerprint of the card struct,
+// the time the source data was updated, the number of cards and the offsets of the following sections:
+//
+//   - strings: every string of every card, stored once and referenced by index
+//   - columns: fixed width arrays of frequently queried fields, readable without decoding cards
+//   - index: the offset of every card in the records section, so single cards can be decoded lazily
+//   - records: each card encoded field by field in declaration order
+//
+// Integers are little endian, or varints inside records.
+package carddb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+)
+
+// SchemaVersion is the version of the format written by Write. Bump it when the layout changes
+const SchemaVersion = 1
+
+// Magic identifies card databases
+var Magic = [4]byte{'M', 'T', 'G', 'B'}
+
+var (
+	ErrNotDB   = errors.New("not a card database")
+	ErrVersion = errors.New("unsupported card database version")
+	ErrSchema  = errors.New("card database was written for a different card schema")
+	ErrCorrupt = errors.New("corrupt card database")
+)
+
+// headerSize is the size of magic, version, reserved, fingerprint, updated at, count and the four section offsets
+const headerSize = 4 + 2 + 2 + 8 + 8 + 4 + 4*8
+
+// noString marks a missing value in string columns
+const noString = math.MaxUint32
+
+// column is a fixed width array holding one value per card
+type column int
+
+const (
+	nameColumn column = iota
+	typeLineColumn
+	oracleIDColumn
+	manaValueColumn
+	colorsColumn
+	colorIdentityColumn
+	setColumn
+	rarityColumn
+	columnCount
+)
+
+var columnWidths = [columnCount]int{
+	nameColumn:          4,
+	typeLineColumn:      4,
+	oracleIDColumn:      16,
+	manaValueColumn:     4,
+	colorsColumn:        4,
+	colorIdentityColumn: 4,
+	setColumn:           4,
+	rarityColumn:        4,
+}
+
+var cardFingerprint = fingerprint(reflect.TypeFor[card.Card]())
+
+// Header describes a card database
+type Header struct {
+	Version uint16
+	// UpdatedAt is when the source bulk data was last updated, zero if unknown
+	UpdatedAt time.Time
+	Count     int
+}
+
+// stringTable interns strings, assigning each distinct string an index
+type stringTable struct {
+	indices map[string]uint32
+	strings []string
+}
+
+func (t *stringTable) intern(s string) uint32 {
+	if i, exists := t.indices[s]; exists {
+		return i
+	}
+	i := uint32(len(t.strings))
+	t.indices[s] = i
+	t.strings = append(t.strings, s)
+	return i
+}
+
+// stringSpans locates the strings of a database in its data, so that opening a database doesn't read every string
+type stringSpans struct {
+	data []byte
+	// string i is data[starts[i]:ends[i]]
+	starts, ends []uint32
+}
+
+// get returns a copy of string i, which stays valid after a mapped database is closed
+func (s *stringSpans) get(i uint64) (string, bool) {
+	if i >= uint64(len(s.starts)) {
+		return "", false
+	}
+	return string(s.data[s.starts[i]:s.ends[i]]), true
+}
+
+// IsDB reports whether data starts like a card database
+func IsDB(data []byte) bool {
+	return bytes.HasPrefix(data, Magic[:])
+}
+
+// Encode returns cards as a card database. updatedAt is when the source data was updated and may be zero
+func Encode(cards []card.Card, updatedAt time.Time) []byte {
+	table := &stringTable{indices: map[string]uint32{}}
+	e := encoder{strings: table}
+	codec := codecFor(reflect.TypeFor[card.Card]())
+
+	index := make([]byte, 0, (len(cards)+1)*8)
+	for i := range cards {
+		index = binary.LittleEndian.AppendUint64(index, uint64(len(e.buf)))
+		codec.encode(&e, reflect.ValueOf(&cards[i]).Elem())
+	}
+	index = binary.LittleEndian.AppendUint64(index, uint64(len(e.buf)))
+
+	var columns []byte
+	colors := func(c *card.Colors) uint32 {
+		if c == nil {
+			return noString
+		}
+		return table.intern(strings.Join(*c, ","))
+	}
+	for col := range columnCount {
+		for i := range cards {
+			c := &cards[i]
+			switch col {
+			case nameColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, table.intern(c.Name))
+			case typeLineColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, table.intern(c.TypeLine))
+			case oracleIDColumn:
+				var id uuid.UUID
+				if c.OracleID != nil {
+					id = *c.OracleID
+				}
+				columns = append(columns, id[:]...)
+			case manaValueColumn:
+				mv := float32(math.NaN())
+				if c.Cmc != nil {
+					mv = *c.Cmc
+				}
+				columns = binary.LittleEndian.AppendUint32(columns, math.Float32bits(mv))
+			case colorsColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, colors(c.Colors))
+			case colorIdentityColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, colors(c.ColorIdentity))
+			case setColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, table.intern(c.Set))
+			case rarityColumn:
+				columns = binary.LittleEndian.AppendUint32(columns, table.intern(c.Rarity))
+			}
+		}
+	}
+
+	var stringSection []byte
+	stringSection = binary.AppendUvarint(stringSection, uint64(len(table.strings)))
+	for _, s := range table.strings {
+		stringSection = binary.AppendUvarint(stringSection, uint64(len(s)))
+		stringSection = append(stringSection, s...)
+	}
+
+	var unixNano int64
+	if !updatedAt.IsZero() {
+		unixNano = updatedAt.UnixNano()
+	}
+	stringsOffset := uint64(headerSize)
+	columnsOffset := stringsOffset + uint64(len(stringSection))
+	indexOffset := columnsOffset + uint64(len(columns))
+	recordsOffset := indexOffset + uint64(len(index))
+
+	out := make([]byte, 0, recordsOffset+uint64(len(e.buf)))
+	out = append(out, Magic[:]...)
+	out = binary.LittleEndian.AppendUint16(out, SchemaVersion)
+	out = binary.LittleEndian.AppendUint16(out, 0)
+	out = binary.LittleEndian.AppendUint64(out, cardFingerprint)
+	out = binary.LittleEndian.AppendUint64(out, uint64(unixNano))
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(cards)))
+	for _, offset := range []uint64{stringsOffset, columnsOffset, indexOffset, recordsOffset} {
+		out = binary.LittleEndian.AppendUint64(out, offset)
+	}
+	out = append(out, stringSection...)
+	out = append(out, columns...)
+	out = append(out, index...)
+	out = append(out, e.buf...)
+	return out
+}
+
+// Write writes cards to w as a card database, see Encode
+func Write(w io.Writer, cards []card.Card, updatedAt time.Time) error {
+	_, err := w.Write(Encode(cards, updatedAt))
+	return err
+}
+
+// DB is an opened card database. Cards are only decoded when requested
+type DB struct {
+	Header
+	data    []byte
+	strings stringSpans
+	columns int
+	offsets int
+	records int
+	lookup  lookup
+	// unmap releases data if it is memory mapped
+	unmap func() error
+}
+
+// Close releases the memory of a mapped database. Cards decoded before Close remain valid, the DB does not
+func (db *DB) Close() error {
+	if db.unmap == nil {
+		return nil
+	}
+	unmap := db.unmap
+	db.unmap = nil
+	return unmap()
+}
+
+// ReadFile opens the card database at path
+func ReadFile(path string) (*DB, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := Open(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Open opens a card database held in data. data must not be modified while the DB is in use
+func Open(data []byte) (*DB, error) {
+	if !IsDB(data) {
+		return nil, ErrNotDB
+	}
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("%w: truncated header", ErrCorrupt)
+	}
+	le := binary.LittleEndian
+	db := &DB{data: data}
+	db.Version = le.Uint16(data[4:])
+	if db.Version != SchemaVersion {
+		return nil, fmt.Errorf("%w: %d, expected %d", ErrVersion, db.Version, SchemaVersion)
+	}
+	if le.Uint64(data[8:]) != cardFingerprint {
+		return nil, ErrSchema
+	}
+	if unixNano := int64(le.Uint64(data[16:])); unixNano != 0 {
+		db.UpdatedAt = time.Unix(0, unixNano).UTC()
+	}
+	db.Count = int(le.Uint32(data[24:]))
+
+	var offsets [4]int
+	for i := range offsets {
+		offset := le.Uint64(data[28+8*i:])
+		if offset > uint64(len(data)) || (i > 0 && offset < uint64(offsets[i-1])) {
+			return nil, fmt.Errorf("%w: invalid section offset %d", ErrCorrupt, offset)
+		}
+		offsets[i] = int(offset)
+	}
+	stringsOffset := offsets[0]
+	db.columns, db.offsets, db.records = offsets[1], offsets[2], offsets[3]
+
+	columnsSize := 0
+	for _, w := range columnWidths {
+		columnsSize += w * db.Count
+	}
+	if db.offsets-db.columns != columnsSize || db.records-db.offsets != (db.Count+1)*8 {
+		return nil, fmt.Errorf("%w: section sizes don't match %d cards", ErrCorrupt, db.Count)
+	}
+	if db.offset(db.Count) != uint64(len(data)-db.records) {
+		return nil, fmt.Errorf("%w: records don't match index", ErrCorrupt)
+	}
+
+	d := decoder{buf: data[:db.columns], pos: stringsOffset}
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(db.columns-d.pos) {
+		return nil, fmt.Errorf("%w: %d strings exceed section", ErrCorrupt, n)
+	}
+	if db.columns > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: strings section exceeds %d bytes", ErrCorrupt, uint32(math.MaxUint32))
+	}
+	db.strings = stringSpans{data: data, starts: make([]uint32, n), ends: make([]uint32, n)}
+	for i := range n {
+		l, err := d.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		start := d.pos
+		if _, err := d.bytes(int(min(l, math.MaxInt32))); err != nil {
+			return nil, err
+		}
+		db.strings.starts[i], db.strings.ends[i] = uint32(start), uint32(d.pos)
+	}
+	return db, nil
+}
+
+func (db *DB) Len() int {
+	return db.Count
+}
+
+// offset returns the offset of card i in the records section
+func (db *DB) offset(i int) uint64 {
+	return binary.LittleEndian.Uint64(db.data[db.offsets+8*i:])
+}
+
+// Card decodes card i
+func (db *DB) Card(i int) (card.Card, error) {
+	var c card.Card
+	err := db.decode(i, &c)
+	return c, err
+}
+
+// decode decodes card i into c, overwriting all of its fields
+func (db *DB) decode(i int, c *card.Card) error {
+	if i < 0 || i >= db.Count {
+		return fmt.Errorf("card %d out of range [0, %d)", i, db.Count)
+	}
+	start, end := db.offset(i), db.offset(i+1)
+	if start > end || end > uint64(len(db.data)-db.records) {
+		return fmt.Errorf("%w: invalid offset of card %d", ErrCorrupt, i)
+	}
+	*c = card.Card{}
+	d := decoder{buf: db.data[db.records : db.records+int(end)], pos: int(start), strings: &db.strings}
+	if err := codecFor(reflect.TypeFor[card.Card]()).decode(&d, reflect.ValueOf(c).Elem()); err != nil {
+		return fmt.Errorf("card %d: %w", i, err)
+	}
+	return nil
+}
+
+// Cards decodes every card
+func (db *DB) Cards() ([]card.Card, error) {
+	cards := make([]card.Card, db.Count)
+	for i := range cards {
+		var err error
+		if cards[i], err = db.Card(i); err != nil {
+			return nil, err
+		}
+	}
+	return cards, nil
+}
+
+// value returns the bytes of column col for card i
+func (db *DB) value(col column, i int) []byte {
+	offset := db.columns
+	for c := range col {
+		offset += columnWidths[c] * db.Count
+	}
+	offset += columnWidths[col] * i
+	return db.data[offset : offset+columnWidths[col]]
+}
+
+func (db *DB) stringValue(col column, i int) (string, bool) {
+	s := binary.LittleEndian.Uint32(db.value(col, i))
+	if s == noString {
+		return "", false
+	}
+	return db.strings.get(uint64(s))
+}
+
+func (db *DB) colorsValue(col column, i int) *card.Colors {
+	s, ok := db.stringValue(col, i)
+	if !ok {
+		return nil
+	}
+	colors := card.Colors{}
+	if s != "" {
+		colors = strings.Split(s, ",")
+	}
+	return &colors
+}
+
+// Name returns the name of card i without decoding it
+func (db *DB) Name(i int) string {
+	s, _ := db.stringValue(nameColumn, i)
+	return s
+}
+
+// TypeLine returns the type line of card i without decoding it
+func (db *DB) TypeLine(i int) string {
+	s, _ := db.stringValue(typeLineColumn, i)
+	return s
+}
+
+// OracleID returns the oracle ID of card i without decoding it, uuid.Nil if it has none
+func (db *DB) OracleID(i int) uuid.UUID {
+	return uuid.UUID(db.value(oracleIDColumn, i))
+}
+
+// ManaValue returns the mana value of card i without decoding it
+func (db *DB) ManaValue(i int) (float32, bool) {
+	mv := math.Float32frombits(binary.LittleEndian.Uint32(db.value(manaValueColumn, i)))
+	return mv, !math.IsNaN(float64(mv))
+}
+
+// Colors returns the colors of card i without decoding it
+func (db *DB) Colors(i int) *card.Colors {
+	return db.colorsValue(colorsColumn, i)
+}
+
+// ColorIdentity returns the color identity of card i without decoding it
+func (db *DB) ColorIdentity(i int) *card.Colors {
+	return db.colorsValue(colorIdentityColumn, i)
+}
+
+// Set returns the set code of card i without decoding it
+func (db *DB) Set(i int) string {
+	s, _ := db.stringValue(setColumn, i)
+	return s
+}
+
+// Rarity returns the rarity of card i without decoding it
+func (db *DB) Rarity(i int) string {
+	s, _ := db.stringValue(rarityColumn, i)
+	return s
+}
+
+// Summary returns card i with only the fields held in columns, the name, type line, oracle ID, mana value, colors,
+// color identity, set and rarity, without decoding it
+func (db *DB) Summary(i int) card.Card {
+	c := card.Card{
+		Name:          db.Name(i),
+		TypeLine:      db.TypeLine(i),
+		Colors:        db.Colors(i),
+		ColorIdentity: db.ColorIdentity(i),
+	}
+	if id := db.OracleID(i); id != uuid.Nil {
+		c.OracleID = &id
+	}
+	if mv, ok := db.ManaValue(i); ok {
+		c.Cmc = &mv
+	}
+	c.Set = db.Set(i)
+	c.Rarity = db.Rarity(i)
+	return c
+}
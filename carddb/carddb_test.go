@@ -0,0 +1,112 @@
+package carddb_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/go-cmp/cmp"
+
+	"mtgBuilder/card"
+	"mtgBuilder/carddb"
+)
+
+// loadCards loads the committed test cards, one of each layout
+func loadCards(t *testing.T) []card.Card {
+	t.Helper()
+	var cards []card.Card
+	for _, file := range []string{"double_faced.json", "flip.json", "mdf.json", "nissa.json", "reversible.json", "split.json"} {
+		content, err := os.ReadFile("../card/testdata/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var c card.Card
+		if err := json.Unmarshal(content, &c); err != nil {
+			t.Fatalf("failed to unmarshal %s: %s", file, err)
+		}
+		cards = append(cards, c)
+	}
+	return cards
+}
+
+func TestRoundtrip(t *testing.T) {
+	cards := loadCards(t)
+	updatedAt := time.Date(2025, 6, 1, 9, 4, 22, 512000000, time.UTC)
+	db, err := carddb.Open(carddb.Encode(cards, updatedAt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Version != carddb.SchemaVersion || !db.UpdatedAt.Equal(updatedAt) || db.Len() != len(cards) {
+		t.Errorf("unexpected header %+v", db.Header)
+	}
+
+	decoded, err := db.Cards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cards, decoded); diff != "" {
+		t.Errorf("roundtrip mismatch (-want +got):\n%s", diff)
+	}
+
+	for i, c := range cards {
+		if db.Name(i) != c.Name || db.TypeLine(i) != c.TypeLine || db.Set(i) != c.Set || db.Rarity(i) != c.Rarity {
+			t.Errorf("card %d: string columns don't match %s", i, c.Name)
+		}
+		if c.OracleID != nil && db.OracleID(i) != *c.OracleID {
+			t.Errorf("card %d: expected oracle id %s, got %s", i, c.OracleID, db.OracleID(i))
+		}
+		if mv, ok := db.ManaValue(i); ok != (c.Cmc != nil) || (ok && mv != *c.Cmc) {
+			t.Errorf("card %d: expected mana value %v, got %v", i, c.Cmc, mv)
+		}
+		if diff := cmp.Diff(c.ColorIdentity, db.ColorIdentity(i)); diff != "" {
+			t.Errorf("card %d: color identity mismatch (-want +got):\n%s", i, diff)
+		}
+		if diff := cmp.Diff(c.Colors, db.Colors(i)); diff != "" {
+			t.Errorf("card %d: colors mismatch (-want +got):\n%s", i, diff)
+		}
+		want := card.Card{Name: c.Name, TypeLine: c.TypeLine, Cmc: c.Cmc, Colors: c.Colors, ColorIdentity: c.ColorIdentity}
+		want.OracleID, want.Set, want.Rarity = c.OracleID, c.Set, c.Rarity
+		if diff := cmp.Diff(want, db.Summary(i)); diff != "" {
+			t.Errorf("card %d: summary mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	db, err := carddb.Open(carddb.Encode(nil, time.Time{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Len() != 0 || !db.UpdatedAt.IsZero() {
+		t.Errorf("unexpected header %+v", db.Header)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	data := carddb.Encode(loadCards(t), time.Time{})
+
+	if _, err := carddb.Open([]byte(`[{"name": "Nissa"}]`)); !errors.Is(err, carddb.ErrNotDB) {
+		t.Errorf("expected %v, got %v", carddb.ErrNotDB, err)
+	}
+
+	version := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint16(version[4:], carddb.SchemaVersion+1)
+	if _, err := carddb.Open(version); !errors.Is(err, carddb.ErrVersion) {
+		t.Errorf("expected %v, got %v", carddb.ErrVersion, err)
+	}
+
+	schema := append([]byte(nil), data...)
+	schema[8]++
+	if _, err := carddb.Open(schema); !errors.Is(err, carddb.ErrSchema) {
+		t.Errorf("expected %v, got %v", carddb.ErrSchema, err)
+	}
+
+	for _, size := range []int{10, len(data) / 2, len(data) - 1} {
+		if _, err := carddb.Open(data[:size]); !errors.Is(err, carddb.ErrCorrupt) {
+			t.Errorf("expected %v when truncated to %d bytes, got %v", carddb.ErrCorrupt, size, err)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package carddb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// codec encodes and decodes values of a single type. Codecs are built once per type by codecFor
+type codec struct {
+	encode func(e *encoder, v reflect.Value)
+	decode func(d *decoder, v reflect.Value) error
+}
+
+// encoder writes values to buf, replacing strings with their index in a shared string table
+type encoder struct {
+	buf     []byte
+	strings *stringTable
+}
+
+func (e *encoder) uvarint(n uint64) {
+	e.buf = binary.AppendUvarint(e.buf, n)
+}
+
+func (e *encoder) varint(n int64) {
+	e.buf = binary.AppendVarint(e.buf, n)
+}
+
+// decoder reads values from buf starting at pos
+type decoder struct {
+	buf     []byte
+	pos     int
+	strings *stringSpans
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	n, size := binary.Uvarint(d.buf[d.pos:])
+	if size <= 0 {
+		return 0, fmt.Errorf("%w: invalid uvarint at %d", ErrCorrupt, d.pos)
+	}
+	d.pos += size
+	return n, nil
+}
+
+func (d *decoder) varint() (int64, error) {
+	n, size := binary.Varint(d.buf[d.pos:])
+	if size <= 0 {
+		return 0, fmt.Errorf("%w: invalid varint at %d", ErrCorrupt, d.pos)
+	}
+	d.pos += size
+	return n, nil
+}
+
+func (d *decoder) bytes(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, fmt.Errorf("%w: unexpected end of data at %d", ErrCorrupt, d.pos)
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	i, err := d.uvarint()
+	if err != nil {
+		return "", err
+	}
+	s, ok := d.strings.get(i)
+	if !ok {
+		return "", fmt.Errorf("%w: string %d out of range", ErrCorrupt, i)
+	}
+	return s, nil
+}
+
+// length reads the length of a slice or map. Lengths are stored plus one so that nil and empty values roundtrip
+func (d *decoder) length() (n int, isNil bool, err error) {
+	l, err := d.uvarint()
+	if err != nil {
+		return 0, false, err
+	}
+	if l == 0 {
+		return 0, true, nil
+	}
+	if l-1 > uint64(len(d.buf)-d.pos) {
+		// every element takes at least a byte
+		return 0, false, fmt.Errorf("%w: length %d exceeds data", ErrCorrupt, l-1)
+	}
+	return int(l - 1), false, nil
+}
+
+var codecs sync.Map
+
+// codecFor returns the codec of t, building it if needed
+func codecFor(t reflect.Type) *codec {
+	if c, ok := codecs.Load(t); ok {
+		return c.(*codec)
+	}
+	// element codecs are built eagerly, so recursive types are not supported. Card types have none
+	c := buildCodec(t)
+	codecs.Store(t, c)
+	return c
+}
+
+func buildCodec(t reflect.Type) *codec {
+	switch t.Kind() {
+	case reflect.String:
+		return &codec{
+			func(e *encoder, v reflect.Value) { e.uvarint(uint64(e.strings.intern(v.String()))) },
+			func(d *decoder, v reflect.Value) error {
+				s, err := d.string()
+				v.SetString(s)
+				return err
+			},
+		}
+	case reflect.Bool:
+		return &codec{
+			func(e *encoder, v reflect.Value) {
+				if v.Bool() {
+					e.buf = append(e.buf, 1)
+				} else {
+					e.buf = append(e.buf, 0)
+				}
+			},
+			func(d *decoder, v reflect.Value) error {
+				b, err := d.bytes(1)
+				if err != nil {
+					return err
+				}
+				v.SetBool(b[0] != 0)
+				return nil
+			},
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &codec{
+			func(e *encoder, v reflect.Value) { e.varint(v.Int()) },
+			func(d *decoder, v reflect.Value) error {
+				n, err := d.varint()
+				v.SetInt(n)
+				return err
+			},
+		}
+	case reflect.Uint8:
+		return &codec{
+			func(e *encoder, v reflect.Value) { e.buf = append(e.buf, uint8(v.Uint())) },
+			func(d *decoder, v reflect.Value) error {
+				b, err := d.bytes(1)
+				if err != nil {
+					return err
+				}
+				v.SetUint(uint64(b[0]))
+				return nil
+			},
+		}
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &codec{
+			func(e *encoder, v reflect.Value) { e.uvarint(v.Uint()) },
+			func(d *decoder, v reflect.Value) error {
+				n, err := d.uvarint()
+				v.SetUint(n)
+				return err
+			},
+		}
+	case reflect.Float32:
+		return &codec{
+			func(e *encoder, v reflect.Value) {
+				e.buf = binary.LittleEndian.AppendUint32(e.buf, math.Float32bits(float32(v.Float())))
+			},
+			func(d *decoder, v reflect.Value) error {
+				b, err := d.bytes(4)
+				if err != nil {
+					return err
+				}
+				v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+				return nil
+			},
+		}
+	case reflect.Float64:
+		return &codec{
+			func(e *encoder, v reflect.Value) {
+				e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v.Float()))
+			},
+			func(d *decoder, v reflect.Value) error {
+				b, err := d.bytes(8)
+				if err != nil {
+					return err
+				}
+				v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+				return nil
+			},
+		}
+	case reflect.Pointer:
+		return pointerCodec(t)
+	case reflect.Slice:
+		return sliceCodec(t)
+	case reflect.Array:
+		return arrayCodec(t)
+	case reflect.Map:
+		return mapCodec(t)
+	case reflect.Struct:
+		return structCodec(t)
+	}
+	panic(fmt.Sprintf("carddb: unsupported type %s", t))
+}
+
+// pointerCodec stores a presence byte followed by the value
+func pointerCodec(t reflect.Type) *codec {
+	elem := codecFor(t.Elem())
+	return &codec{
+		func(e *encoder, v reflect.Value) {
+			if v.IsNil() {
+				e.buf = append(e.buf, 0)
+				return
+			}
+			e.buf = append(e.buf, 1)
+			elem.encode(e, v.Elem())
+		},
+		func(d *decoder, v reflect.Value) error {
+			b, err := d.bytes(1)
+			if err != nil || b[0] == 0 {
+				return err
+			}
+			p := reflect.New(t.Elem())
+			if err := elem.decode(d, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		},
+	}
+}
+
+func sliceCodec(t reflect.Type) *codec {
+	elem := codecFor(t.Elem())
+	return &codec{
+		func(e *encoder, v reflect.Value) {
+			if v.IsNil() {
+				e.uvarint(0)
+				return
+			}
+			e.uvarint(uint64(v.Len()) + 1)
+			for i := range v.Len() {
+				elem.encode(e, v.Index(i))
+			}
+		},
+		func(d *decoder, v reflect.Value) error {
+			n, isNil, err := d.length()
+			if err != nil || isNil {
+				return err
+			}
+			s := reflect.MakeSlice(t, n, n)
+			for i := range n {
+				if err := elem.decode(d, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+			return nil
+		},
+	}
+}
+
+func arrayCodec(t reflect.Type) *codec {
+	elem := codecFor(t.Elem())
+	return &codec{
+		func(e *encoder, v reflect.Value) {
+			for i := range v.Len() {
+				elem.encode(e, v.Index(i))
+			}
+		},
+		func(d *decoder, v reflect.Value) error {
+			for i := range v.Len() {
+				if err := elem.decode(d, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// mapCodec stores entries sorted by key so that encoding is deterministic. Only string keys are supported
+func mapCodec(t reflect.Type) *codec {
+	if t.Key().Kind() != reflect.String {
+		panic(fmt.Sprintf("carddb: unsupported map key %s", t.Key()))
+	}
+	key, elem := codecFor(t.Key()), codecFor(t.Elem())
+	return &codec{
+		func(e *encoder, v reflect.Value) {
+			if v.IsNil() {
+				e.uvarint(0)
+				return
+			}
+			e.uvarint(uint64(v.Len()) + 1)
+			keys := v.MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+			for _, k := range keys {
+				key.encode(e, k)
+				elem.encode(e, v.MapIndex(k))
+			}
+		},
+		func(d *decoder, v reflect.Value) error {
+			n, isNil, err := d.length()
+			if err != nil || isNil {
+				return err
+			}
+			m := reflect.MakeMapWithSize(t, n)
+			for range n {
+				k := reflect.New(t.Key()).Elem()
+				if err := key.decode(d, k); err != nil {
+					return err
+				}
+				val := reflect.New(t.Elem()).Elem()
+				if err := elem.decode(d, val); err != nil {
+					return err
+				}
+				m.SetMapIndex(k, val)
+			}
+			v.Set(m)
+			return nil
+		},
+	}
+}
+
+// structCodec stores exported fields in declaration order
+func structCodec(t reflect.Type) *codec {
+	var fields []int
+	var fieldCodecs []*codec
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			fields = append(fields, i)
+			fieldCodecs = append(fieldCodecs, codecFor(t.Field(i).Type))
+		}
+	}
+	return &codec{
+		func(e *encoder, v reflect.Value) {
+			for j, i := range fields {
+				fieldCodecs[j].encode(e, v.Field(i))
+			}
+		},
+		func(d *decoder, v reflect.Value) error {
+			for j, i := range fields {
+				if err := fieldCodecs[j].decode(d, v.Field(i)); err != nil {
+					return fmt.Errorf("%s.%s: %w", t.Name(), t.Field(i).Name, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// fingerprint hashes the layout of t so that files written for a different card struct are rejected
+func fingerprint(t reflect.Type) uint64 {
+	h := fnv.New64a()
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		fmt.Fprintf(h, "%s(", t.Kind())
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice:
+			walk(t.Elem())
+		case reflect.Array:
+			fmt.Fprintf(h, "%d", t.Len())
+			walk(t.Elem())
+		case reflect.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case reflect.Struct:
+			for i := range t.NumField() {
+				if f := t.Field(i); f.IsExported() {
+					fmt.Fprintf(h, "%s:", f.Name)
+					walk(f.Type)
+				}
+			}
+		}
+		fmt.Fprint(h, ")")
+	}
+	walk(t)
+	return h.Sum64()
+}
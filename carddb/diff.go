@@ -0,0 +1,241 @@
+package carddb
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+)
+
+// ChangeKind is how a card changed between two snapshots
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Changed
+)
+
+var changeKindNames = []string{"added", "removed", "changed"}
+
+func (k ChangeKind) String() string {
+	if k < 0 || int(k) >= len(changeKindNames) {
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+	return changeKindNames[k]
+}
+
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// FieldChange is a field of a card that changed between two snapshots. Old or New is empty if the field was added or removed
+type FieldChange struct {
+	// Field is the name of the field, ex. type_line, legalities.modern or prices.usd
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	// PrintingID is the ID of the printing of a field that differs between printings, such as prices
+	PrintingID uuid.UUID `json:"printing_id,omitzero"`
+	// Printing is the set and collector number of the printing, ex. cmr 73
+	Printing string `json:"printing,omitempty"`
+}
+
+// Change is a card that was added, removed or changed between two snapshots
+type Change struct {
+	OracleID uuid.UUID  `json:"oracle_id"`
+	Name     string     `json:"name"`
+	Kind     ChangeKind `json:"kind"`
+	// Fields are the changed fields of a Changed card
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Diff compares the cards of two snapshots by oracle ID. Oracle fields are compared on the first printing of each card,
+// prices are compared between printings with the same ID so that snapshots with many printings can be compared.
+// Changes are ordered by kind, then name
+func Diff(before, after CardStore) ([]Change, error) {
+	old, err := newSnapshot(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index old cards: %w", err)
+	}
+	cur, err := newSnapshot(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index new cards: %w", err)
+	}
+
+	var changes []Change
+	for id, printings := range cur.oracles {
+		c, err := after.Get(printings[0])
+		if err != nil {
+			return nil, err
+		}
+		oldPrintings, exists := old.oracles[id]
+		if !exists {
+			changes = append(changes, Change{OracleID: id, Name: c.Name, Kind: Added})
+			continue
+		}
+		prev, err := before.Get(oldPrintings[0])
+		if err != nil {
+			return nil, err
+		}
+		fields := diffFields(oracleValues(prev), oracleValues(c))
+		priceChanges, err := diffPrices(before, after, old, printings)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, priceChanges...)
+		if len(fields) > 0 {
+			changes = append(changes, Change{OracleID: id, Name: c.Name, Kind: Changed, Fields: fields})
+		}
+	}
+	for id, printings := range old.oracles {
+		if _, exists := cur.oracles[id]; exists {
+			continue
+		}
+		c, err := before.Get(printings[0])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{OracleID: id, Name: c.Name, Kind: Removed})
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		if c := cmp.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.OracleID.String(), b.OracleID.String())
+	})
+	return changes, nil
+}
+
+// snapshot indexes the cards of a store by oracle ID and by printing
+type snapshot struct {
+	// oracles are the indices of the printings of each oracle ID, in store order
+	oracles map[uuid.UUID][]int
+	// printings are the index of each card ID
+	printings map[uuid.UUID]int
+}
+
+func newSnapshot(store CardStore) (snapshot, error) {
+	s := snapshot{map[uuid.UUID][]int{}, map[uuid.UUID]int{}}
+	err := store.Iterate(func(i int, c *card.Card) error {
+		if c.ID != uuid.Nil {
+			s.printings[c.ID] = i
+		}
+		if oracleIDs := c.OracleIDs(); len(oracleIDs) > 0 {
+			s.oracles[oracleIDs[0]] = append(s.oracles[oracleIDs[0]], i)
+		}
+		return nil
+	})
+	return s, err
+}
+
+// diffPrices compares the prices of each of printings of after with the printing of before with the same ID
+func diffPrices(before, after CardStore, old snapshot, printings []int) ([]FieldChange, error) {
+	var changes []FieldChange
+	for _, i := range printings {
+		c, err := after.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		j, exists := old.printings[c.ID]
+		if !exists {
+			continue
+		}
+		prev, err := before.Get(j)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range diffFields(priceValues(prev), priceValues(c)) {
+			change.PrintingID = c.ID
+			change.Printing = c.Set + " " + c.CollectorNumber
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// oracleValues returns the fields of c shared by all of its printings by name. Fields without a value are left out
+func oracleValues(c *card.Card) map[string]string {
+	values := map[string]string{
+		"name":        c.Name,
+		"type_line":   c.TypeLine,
+		"oracle_text": strings.Join(c.GetOracleText(), "\n"),
+	}
+	faces := c.Faces()
+	faceValue := func(value func(f card.Face) string) string {
+		var parts []string
+		for _, f := range faces {
+			if v := value(f); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		return strings.Join(parts, " // ")
+	}
+	values["mana_cost"] = faceValue(func(f card.Face) string { return f.ManaCost })
+	values["power"] = faceValue(func(f card.Face) string { return deref(f.Power) })
+	values["toughness"] = faceValue(func(f card.Face) string { return deref(f.Toughness) })
+	values["loyalty"] = faceValue(func(f card.Face) string { return deref(f.Loyalty) })
+	values["defense"] = faceValue(func(f card.Face) string { return deref(f.Defense) })
+	if c.ColorIdentity != nil {
+		values["color_identity"] = strings.Join(*c.ColorIdentity, "")
+	}
+	for format, legality := range c.Legalities {
+		values["legalities."+string(format)] = string(legality)
+	}
+	maps.DeleteFunc(values, func(_, v string) bool { return v == "" })
+	return values
+}
+
+// priceValues returns the prices of the printing c by name. Missing prices are left out
+func priceValues(c *card.Card) map[string]string {
+	values := map[string]string{}
+	prices := map[string]*string{
+		"usd":        c.Prices.Usd,
+		"usd_foil":   c.Prices.UsdFoil,
+		"usd_etched": c.Prices.UsdEtched,
+		"eur":        c.Prices.Eur,
+		"eur_foil":   c.Prices.EurFoil,
+		"eur_etched": c.Prices.EurEtched,
+		"tix":        c.Prices.Tix,
+	}
+	for name, price := range prices {
+		values["prices."+name] = deref(price)
+	}
+	maps.DeleteFunc(values, func(_, v string) bool { return v == "" })
+	return values
+}
+
+// diffFields returns the fields that differ between before and after, ordered by name
+func diffFields(before, after map[string]string) []FieldChange {
+	fields := slices.Collect(maps.Keys(after))
+	for field := range before {
+		if _, exists := after[field]; !exists {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		if before[field] != after[field] {
+			changes = append(changes, FieldChange{Field: field, Old: before[field], New: after[field]})
+		}
+	}
+	return changes
+}
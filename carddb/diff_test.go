@@ -0,0 +1,118 @@
+package carddb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+	"mtgBuilder/carddb"
+)
+
+func TestDiff(t *testing.T) {
+	before := loadCards(t)
+	after := loadCards(t)
+
+	// Nissa is removed, Erayo is added, Extus is banned and reprinted at a new price
+	var nissa, erayo, extus int
+	for i, c := range before {
+		switch c.Name {
+		case "Nissa, Worldsoul Speaker":
+			nissa = i
+		case "Erayo, Soratami Ascendant // Erayo's Essence":
+			erayo = i
+		case "Extus, Oriq Overlord // Awaken the Blood Avatar":
+			extus = i
+		}
+	}
+	after[extus].Legalities = card.Legalities{}
+	for format, legality := range before[extus].Legalities {
+		after[extus].Legalities[format] = legality
+	}
+	after[extus].Legalities["modern"] = "banned"
+	usd := "3.50"
+	after[extus].Prices.Usd = &usd
+	after[extus].TypeLine = "Legendary Creature — Human Wizard // Sorcery"
+	extusID := after[extus].ID
+	after = append(after[:nissa], after[nissa+1:]...)
+	before = append(before[:erayo], before[erayo+1:]...)
+
+	changes, err := carddb.Diff(carddb.NewMemoryStore(before), carddb.NewMemoryStore(after))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Name   string
+		Kind   carddb.ChangeKind
+		Fields []carddb.FieldChange
+	}
+	var got []summary
+	for _, c := range changes {
+		got = append(got, summary{c.Name, c.Kind, c.Fields})
+	}
+	expected := []summary{
+		{"Erayo, Soratami Ascendant // Erayo's Essence", carddb.Added, nil},
+		{"Nissa, Worldsoul Speaker", carddb.Removed, nil},
+		{"Extus, Oriq Overlord // Awaken the Blood Avatar", carddb.Changed, []carddb.FieldChange{
+			{Field: "legalities.modern", Old: "legal", New: "banned"},
+			{Field: "type_line", Old: "Legendary Creature — Human Warlock // Sorcery", New: "Legendary Creature — Human Wizard // Sorcery"},
+			{Field: "prices.usd", Old: "0.48", New: "3.50", PrintingID: extusID, Printing: "stx 149"},
+		}},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected changes (-expected +got):\n%s", diff)
+	}
+}
+
+func TestDiffPrintings(t *testing.T) {
+	original := loadCards(t)[0]
+	reprint := original
+	reprint.ID = uuid.New()
+	reprint.Set = "tst"
+	reprint.CollectorNumber = "1"
+	usd, eur := "1.00", "2.00"
+	reprint.Prices.Usd = &usd
+	reprint.Prices.Eur = &eur
+
+	// the printings are listed in a different order and only the reprint's usd price changes
+	repriced := reprint
+	newUsd := "1.25"
+	repriced.Prices.Usd = &newUsd
+	before := []card.Card{original, reprint}
+	after := []card.Card{repriced, original}
+
+	changes, err := carddb.Diff(carddb.NewMemoryStore(before), carddb.NewMemoryStore(after))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected a single change, got %+v", changes)
+	}
+	expected := []carddb.FieldChange{
+		{Field: "prices.usd", Old: "1.00", New: "1.25", PrintingID: reprint.ID, Printing: "tst 1"},
+	}
+	if diff := cmp.Diff(expected, changes[0].Fields); diff != "" {
+		t.Errorf("unexpected fields (-expected +got):\n%s", diff)
+	}
+
+	changes, err = carddb.Diff(carddb.NewMemoryStore(before), carddb.NewMemoryStore([]card.Card{reprint, original}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected reordered printings to be unchanged, got %+v", changes)
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	cards := loadCards(t)
+	changes, err := carddb.Diff(carddb.NewMemoryStore(cards), carddb.NewMemoryStore(cards))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
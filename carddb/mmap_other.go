@@ -0,0 +1,8 @@
+//go:build !unix
+
+package carddb
+
+// Mmap reads the card database at path into memory on platforms without mmap
+func Mmap(path string) (*DB, error) {
+	return ReadFile(path)
+}
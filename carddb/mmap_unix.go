@@ -0,0 +1,37 @@
+//go:build unix
+
+package carddb
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Mmap opens the card database at path by mapping it into memory, so only the pages of accessed cards are read.
+// The DB must be closed to unmap the file
+func Mmap(path string) (*DB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < headerSize {
+		return nil, fmt.Errorf("%s: %w", path, ErrNotDB)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", path, err)
+	}
+	db, err := Open(data)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	db.unmap = func() error { return syscall.Munmap(data) }
+	return db, nil
+}
@@ -0,0 +1,194 @@
+package carddb
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+)
+
+// CardStore is a read-only, indexed collection of cards
+type CardStore interface {
+	Len() int
+	// Get returns card i. The card must not be modified
+	Get(i int) (*card.Card, error)
+	// ByOracleID returns the indices of the printings of a card
+	ByOracleID(id uuid.UUID) []int
+	// ByName returns the indices of the cards with a name, or a face with a name, equal to name ignoring case
+	ByName(name string) []int
+	// Iterate calls f with every card in order, stopping at the first error from decoding or f.
+	// The card passed to f may be reused, so f must copy it to keep it
+	Iterate(f func(i int, c *card.Card) error) error
+}
+
+// All returns an iterator over the cards of store, and a function returning the error that ended the iteration early, if any.
+// Unlike Iterate, the yielded cards are not reused so they may be kept, such as by query.Search
+func All(store CardStore) (iter.Seq2[int, *card.Card], func() error) {
+	var err error
+	seq := func(yield func(int, *card.Card) bool) {
+		for i := range store.Len() {
+			var c *card.Card
+			if c, err = store.Get(i); err != nil {
+				return
+			}
+			if !yield(i, c) {
+				return
+			}
+		}
+	}
+	return seq, func() error { return err }
+}
+
+// summarizer is implemented by stores that can read some fields of a card without decoding it, see DB.Summary
+type summarizer interface {
+	Summary(i int) card.Card
+}
+
+// Candidates is like All, but skips the cards of a DB whose summary keep rejects without decoding them.
+// keep must only reject cards it can tell don't match from the fields of a summary. Other stores yield every card
+func Candidates(store CardStore, keep func(summary *card.Card) bool) (iter.Seq2[int, *card.Card], func() error) {
+	s, ok := store.(summarizer)
+	if !ok {
+		return All(store)
+	}
+	var err error
+	seq := func(yield func(int, *card.Card) bool) {
+		for i := range store.Len() {
+			if summary := s.Summary(i); !keep(&summary) {
+				continue
+			}
+			var c *card.Card
+			if c, err = store.Get(i); err != nil {
+				return
+			}
+			if !yield(i, c) {
+				return
+			}
+		}
+	}
+	return seq, func() error { return err }
+}
+
+// nameKeys returns the keys a card with name is found by in ByName
+func nameKeys(name string) []string {
+	name = strings.ToLower(name)
+	keys := []string{name}
+	if faces := strings.Split(name, " // "); len(faces) > 1 {
+		keys = append(keys, faces...)
+	}
+	return keys
+}
+
+// lookup indexes cards by name and oracle ID on first use
+type lookup struct {
+	once      sync.Once
+	names     map[string][]int
+	oracleIDs map[uuid.UUID][]int
+}
+
+func (l *lookup) build(n int, name func(i int) string, oracleID func(i int) uuid.UUID) {
+	l.once.Do(func() {
+		l.names = map[string][]int{}
+		l.oracleIDs = map[uuid.UUID][]int{}
+		for i := range n {
+			for _, key := range nameKeys(name(i)) {
+				l.names[key] = append(l.names[key], i)
+			}
+			if id := oracleID(i); id != uuid.Nil {
+				l.oracleIDs[id] = append(l.oracleIDs[id], i)
+			}
+		}
+	})
+}
+
+// MemoryStore is a CardStore of decoded cards
+type MemoryStore struct {
+	cards  []card.Card
+	lookup lookup
+}
+
+func NewMemoryStore(cards []card.Card) *MemoryStore {
+	return &MemoryStore{cards: cards}
+}
+
+func (s *MemoryStore) Len() int {
+	return len(s.cards)
+}
+
+func (s *MemoryStore) Get(i int) (*card.Card, error) {
+	if i < 0 || i >= len(s.cards) {
+		return nil, fmt.Errorf("card %d out of range [0, %d)", i, len(s.cards))
+	}
+	return &s.cards[i], nil
+}
+
+func (s *MemoryStore) index() *lookup {
+	s.lookup.build(len(s.cards),
+		func(i int) string { return s.cards[i].Name },
+		func(i int) uuid.UUID {
+			if s.cards[i].OracleID == nil {
+				return uuid.Nil
+			}
+			return *s.cards[i].OracleID
+		})
+	return &s.lookup
+}
+
+func (s *MemoryStore) ByOracleID(id uuid.UUID) []int {
+	return s.index().oracleIDs[id]
+}
+
+func (s *MemoryStore) ByName(name string) []int {
+	return s.index().names[strings.ToLower(name)]
+}
+
+func (s *MemoryStore) Iterate(f func(i int, c *card.Card) error) error {
+	for i := range s.cards {
+		if err := f(i, &s.cards[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get decodes card i. Cards are decoded on every call
+func (db *DB) Get(i int) (*card.Card, error) {
+	c, err := db.Card(i)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (db *DB) index() *lookup {
+	db.lookup.build(db.Count, db.Name, db.OracleID)
+	return &db.lookup
+}
+
+// ByOracleID finds cards by the oracle ID column without decoding them
+func (db *DB) ByOracleID(id uuid.UUID) []int {
+	return db.index().oracleIDs[id]
+}
+
+// ByName finds cards by the name column without decoding them
+func (db *DB) ByName(name string) []int {
+	return db.index().names[strings.ToLower(name)]
+}
+
+// Iterate decodes every card in order into a single reused card
+func (db *DB) Iterate(f func(i int, c *card.Card) error) error {
+	var c card.Card
+	for i := range db.Count {
+		if err := db.decode(i, &c); err != nil {
+			return err
+		}
+		if err := f(i, &c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
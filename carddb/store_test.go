@@ -0,0 +1,145 @@
+package carddb_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"mtgBuilder/card"
+	"mtgBuilder/carddb"
+)
+
+func TestStores(t *testing.T) {
+	cards := loadCards(t)
+	path := filepath.Join(t.TempDir(), "cards.db")
+	if err := os.WriteFile(path, carddb.Encode(cards, time.Time{}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mapped, err := carddb.Mmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mapped.Close()
+	read, err := carddb.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stores := map[string]carddb.CardStore{
+		"memory": carddb.NewMemoryStore(cards),
+		"mmap":   mapped,
+		"read":   read,
+	}
+	for name, store := range stores {
+		if store.Len() != len(cards) {
+			t.Errorf("%s: expected %d cards, got %d", name, len(cards), store.Len())
+		}
+
+		var iterated []card.Card
+		err := store.Iterate(func(i int, c *card.Card) error {
+			if i != len(iterated) {
+				t.Errorf("%s: expected index %d, got %d", name, len(iterated), i)
+			}
+			iterated = append(iterated, *c)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if diff := cmp.Diff(cards, iterated); diff != "" {
+			t.Errorf("%s: iterated cards mismatch (-want +got):\n%s", name, diff)
+		}
+
+		// the cards yielded by All are kept, so they must not be reused
+		all, iterErr := carddb.All(store)
+		var kept []*card.Card
+		for _, c := range all {
+			kept = append(kept, c)
+		}
+		if err := iterErr(); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		for i, c := range kept {
+			if c.Name != cards[i].Name {
+				t.Errorf("%s: expected kept card %d to be %s, got %s", name, i, cards[i].Name, c.Name)
+			}
+		}
+
+		// only a DB can reject cards by their summary, other stores yield every card
+		candidates, iterErr := carddb.Candidates(store, func(summary *card.Card) bool { return summary.Set != cards[0].Set })
+		var candidateNames []string
+		for _, c := range candidates {
+			candidateNames = append(candidateNames, c.Name)
+		}
+		if err := iterErr(); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		var want []string
+		for _, c := range cards {
+			if name == "memory" || c.Set != cards[0].Set {
+				want = append(want, c.Name)
+			}
+		}
+		if !slices.Equal(candidateNames, want) {
+			t.Errorf("%s: expected candidates %v, got %v", name, want, candidateNames)
+		}
+
+		for i, c := range cards {
+			got, err := store.Get(i)
+			if err != nil {
+				t.Fatalf("%s: %s", name, err)
+			}
+			if got.Name != c.Name {
+				t.Errorf("%s: expected card %d to be %s, got %s", name, i, c.Name, got.Name)
+			}
+			if !slices.Contains(store.ByName(c.Name), i) {
+				t.Errorf("%s: %s not found by name", name, c.Name)
+			}
+			if c.OracleID != nil && !slices.Contains(store.ByOracleID(*c.OracleID), i) {
+				t.Errorf("%s: %s not found by oracle id", name, c.Name)
+			}
+		}
+		if _, err := store.Get(len(cards)); err == nil {
+			t.Errorf("%s: expected an error getting card %d", name, len(cards))
+		}
+
+		for _, n := range []string{"nissa, worldsoul speaker", "ERAYO, SORATAMI ASCENDANT", "Erayo's Essence"} {
+			if len(store.ByName(n)) == 0 {
+				t.Errorf("%s: %s not found by name", name, n)
+			}
+		}
+		if found := store.ByName("nissa"); len(found) != 0 {
+			t.Errorf("%s: expected no card named nissa, got %v", name, found)
+		}
+	}
+}
+
+func TestMappedCardsOutliveClose(t *testing.T) {
+	cards := loadCards(t)
+	path := filepath.Join(t.TempDir(), "cards.db")
+	if err := os.WriteFile(path, carddb.Encode(cards, time.Time{}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := carddb.Mmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := db.Cards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := db.Summary(0)
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cards, decoded); diff != "" {
+		t.Errorf("cards decoded before Close changed (-want +got):\n%s", diff)
+	}
+	if summary.Name != cards[0].Name {
+		t.Errorf("expected the summary to keep the name %s, got %s", cards[0].Name, summary.Name)
+	}
+}
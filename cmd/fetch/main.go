@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io"
 	"log"
 	"os"
 
@@ -17,12 +18,13 @@ func main() {
 		log.Fatal(err)
 	}
 
-	js, err := fetch.GetOracleCardsJSON()
+	body, _, err := fetch.OpenBulkData("oracle_cards")
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer body.Close()
 
-	_, err = f.Write(js)
+	_, err = io.Copy(f, body)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"cmp"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -18,57 +20,110 @@ import (
 	"time"
 
 	"mtgBuilder/card"
+	"mtgBuilder/carddb"
 	"mtgBuilder/fetch"
 	"mtgBuilder/query"
+	"mtgBuilder/symbology"
 )
 
-func serializeCards(path string, cards []card.Card) error {
+// writeGzipJSON writes cards as gzip'd JSON, the format of cards.bin before card databases
+func writeGzipJSON(w io.Writer, cards []card.Card) error {
 	j, err := json.Marshal(cards)
 	if err != nil {
 		return fmt.Errorf("failed to serialize cards: %w", err)
 	}
-	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o0644)
+	gz, err := gzip.NewWriterLevel(w, gzip.BestCompression)
 	if err != nil {
-		return fmt.Errorf("failed to write to %s: %w", path, err)
+		panic(fmt.Errorf("compression level should always be valid: %w", err))
 	}
-	defer f.Close()
+	if _, err := gz.Write(j); err != nil {
+		return err
+	}
+	return gz.Close()
+}
 
-	w, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+func readGzipJSON(r io.Reader) ([]card.Card, error) {
+	gz, err := gzip.NewReader(r)
 	if err != nil {
-		panic(fmt.Errorf("compression level should always be valid: %w", err))
+		return nil, err
+	}
+	defer gz.Close()
+	return card.DecodeCards(gz, nil)
+}
+
+// serializeCards writes cards to path as a card database, or as gzip'd JSON if format is json
+func serializeCards(path string, cards []card.Card, format string, updatedAt time.Time) error {
+	if format != "db" && format != "json" {
+		return fmt.Errorf("unknown format '%s'", format)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", path, err)
 	}
 
-	_, err = w.Write(j)
+	if format == "db" {
+		err = carddb.Write(f, cards, updatedAt)
+	} else {
+		err = writeGzipJSON(f, cards)
+	}
+	// the file is closed once, keeping the first error
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to write to %s: %w", path, err)
 	}
-	return w.Close()
+	return nil
 }
 
+// deserializeCards reads the cards at path, which may be a card database or gzip'd JSON
 func deserializeCards(path string) ([]card.Card, error) {
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open to %s: %w", path, err)
 	}
-	defer f.Close()
 
-	w, err := gzip.NewReader(f)
+	var cards []card.Card
+	if carddb.IsDB(data) {
+		var db *carddb.DB
+		db, err = carddb.Open(data)
+		if err == nil {
+			slog.Debug("opened card database", "path", path, "updated_at", db.UpdatedAt, "cards", db.Len())
+			cards, err = db.Cards()
+		}
+	} else {
+		cards, err = readGzipJSON(bytes.NewReader(data))
+	}
 	if err != nil {
-		panic(fmt.Errorf("failed to read %s: %s", path, err))
+		return nil, fmt.Errorf("failed to deserialize cards from %s: %w", path, err)
 	}
-	defer w.Close()
+	return cards, nil
+}
 
-	j, err := io.ReadAll(w)
+// openStore opens the cards at path. Card databases are memory mapped and decoded lazily, gzip'd JSON is decoded up front.
+// The store must be closed with closeStore
+func openStore(path string) (carddb.CardStore, error) {
+	db, err := carddb.Mmap(path)
+	if err == nil {
+		slog.Debug("opened card database", "path", path, "updated_at", db.UpdatedAt, "cards", db.Len())
+		return db, nil
+	}
+	if !errors.Is(err, carddb.ErrNotDB) {
+		return nil, err
+	}
+	cards, err := deserializeCards(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read to %s: %w", path, err)
+		return nil, err
 	}
+	return carddb.NewMemoryStore(cards), nil
+}
 
-	var cards []card.Card
-	err = json.Unmarshal(j, &cards)
-	if err != nil {
-		return nil, fmt.Errorf("failed to deserialize cards from %s: %w", path, err)
+func closeStore(store carddb.CardStore) {
+	if c, ok := store.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			slog.Error("failed to close card store", "err", err)
+		}
 	}
-	return cards, nil
 }
 
 var subcommands = map[string]struct {
@@ -81,25 +136,40 @@ var subcommands = map[string]struct {
 		"cards.bin",
 		func(flags *flag.FlagSet, args []string) {
 			jsonPath := flags.String("jsonPath", "", "path the a pre-fetched cards.json")
+			bulk := flags.String("bulk", "oracle_cards", "the type of bulk data to fetch, all_cards includes non-english printings")
+			lang := flags.String("lang", "", "only keep printings in this language, ex. en")
+			format := flags.String("format", "db", "the format to write, db for a card database or json for gzip'd json")
+			rulings := flags.Bool("rulings", false, "attach the rulings of each card, which are copied to every printing and grow all_cards databases considerably")
+			rulingsPath := flags.String("rulingsPath", "", "path to a pre-fetched rulings.json")
 			flags.Parse(args)
 			const NARGS = 1
 			if flags.NArg() != NARGS {
 				fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
 				flags.Usage()
 			}
-			var js []byte
+			var r io.ReadCloser
+			var updatedAt time.Time
 			var err error
 			if *jsonPath == "" {
-				js, err = fetch.GetOracleCardsJSON()
+				var entry fetch.BulkDataEntry
+				r, entry, err = fetch.OpenBulkData(*bulk)
+				if err == nil {
+					updatedAt, err = entry.Updated()
+				}
 			} else {
-				js, err = os.ReadFile(*jsonPath)
+				r, err = os.Open(*jsonPath)
 			}
 			if err != nil {
 				log.Fatal(err)
 			}
+			defer r.Close()
 
-			var cards []card.Card
-			if err = json.Unmarshal(js, &cards); err != nil {
+			var keep func(c *card.Card) bool
+			if *lang != "" {
+				keep = func(c *card.Card) bool { return c.Lang == *lang }
+			}
+			cards, err := card.DecodeCards(r, keep)
+			if err != nil {
 				log.Fatal(err)
 			}
 
@@ -107,13 +177,41 @@ var subcommands = map[string]struct {
 				panic("serializing 0 cards")
 			}
 
-			if err := serializeCards(flags.Arg(0), cards); err != nil {
+			if *rulings {
+				r, err := loadRulings(*rulingsPath)
+				if err != nil {
+					log.Fatal(err)
+				}
+				r.Attach(cards)
+			}
+
+			if err := serializeCards(flags.Arg(0), cards, *format, updatedAt); err != nil {
 				log.Fatal(err)
 			}
 		},
 	},
+	"rulings": {
+		"print the official rulings of a card",
+		"cards.bin 'Clone'",
+		rulingsCmd,
+	},
+	"sets": {
+		"list sets by release date, fetching them first with -fetch",
+		"-fetch -block dom sets.json",
+		setsCmd,
+	},
+	"validate": {
+		"report scryfall card fields that don't match the card model, exiting with 1 if any are found",
+		"cards.json",
+		validateCmd,
+	},
+	"diff": {
+		"print the cards added, removed or changed between two snapshots",
+		"-json old.bin new.bin",
+		diffCmd,
+	},
 	"benchdecode": {
-		"measure the time to decode cards.bin (requires -time)",
+		"compare the time to decode cards.bin as a card database and as gzip'd json",
 		"cards.bin",
 		func(flags *flag.FlagSet, args []string) {
 			flags.Parse(args)
@@ -124,11 +222,39 @@ var subcommands = map[string]struct {
 				flags.Usage()
 			}
 
+			start := time.Now()
 			cards, err := deserializeCards(flags.Arg(0))
 			if err != nil {
 				log.Fatal(err)
 			}
-			log.Printf("decoded %d cards\n", len(cards))
+			log.Printf("decoded %d cards from %s in %s\n", len(cards), flags.Arg(0), time.Since(start))
+
+			var gz bytes.Buffer
+			if err := writeGzipJSON(&gz, cards); err != nil {
+				log.Fatal(err)
+			}
+			db := carddb.Encode(cards, time.Time{})
+
+			start = time.Now()
+			if _, err := readGzipJSON(bytes.NewReader(gz.Bytes())); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("json:\t%d bytes\tdecoded in %s\n", gz.Len(), time.Since(start))
+
+			start = time.Now()
+			opened, err := carddb.Open(db)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opening := time.Since(start)
+			for i := range opened.Len() {
+				opened.Name(i)
+			}
+			names := time.Since(start)
+			if _, err := opened.Cards(); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("db:\t%d bytes\tdecoded in %s (opened in %s, names read in %s)\n", len(db), time.Since(start), opening, names)
 		},
 	},
 	"search": {
@@ -146,13 +272,32 @@ var subcommands = map[string]struct {
 		"-max 20 :8099 'oracle:/:.*goblin/'",
 		queryCmd,
 	},
+	"explain": {
+		"explain why a card does or does not match a query",
+		"cards.bin 't:goblin o:haste' 'Goblin Guide'",
+		explainCmd,
+	},
+	"related": {
+		"print the tokens, meld parts and combo pieces related to a card",
+		"cards.bin 'Arlinn Kord'",
+		relatedCmd,
+	},
 }
 
 func searchCmd(flags *flag.FlagSet, args []string) {
 	maxArg := flags.Uint("max", 5, "set the max amount of cards to print")
 	short := flags.Bool("short", false, "show short output")
+	facets := flags.Bool("facets", false, "print a summary of the matches")
+	filter := flags.String("filter", "default", "the default filter to apply")
+	macros := flags.String("macros", "", "path to a JSON or TOML file of query macros")
+	sets := flags.String("sets", "", "path to a sets.json, required by block: and set release order queries such as set>=dom")
+	symbols := flags.String("symbols", "auto", "how to print mana symbols: text, unicode, ansi, html, or auto for ansi on terminals")
+	symbologyPath := flags.String("symbology", "", "path to a symbology.json to use instead of the built in symbols")
 	flags.Parse(args)
+	loadMacros(*macros)
+	loadSets(*sets)
 	printMax := int(*maxArg)
+	symbolFormat, symbolTable := loadSymbology(*symbols, *symbologyPath)
 
 	const NARGS = 2
 	if flags.NArg() != NARGS {
@@ -163,42 +308,444 @@ func searchCmd(flags *flag.FlagSet, args []string) {
 	queryString := flags.Arg(1)
 	cardsPath := flags.Arg(0)
 
-	q, err := query.Parse(queryString, true)
+	q, err := query.ParseWithFilter(queryString, *filter)
 	if err != nil {
 		log.Fatalf("failed to parse query '%s': %s", queryString, err)
 	}
 	slog.Info("Parsed Query", "query", q)
 
-	cards, err := deserializeCards(cardsPath)
+	store, err := openStore(cardsPath)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer closeStore(store)
 
 	start := time.Now()
-	var matches []int
-	for i, c := range cards {
-		if q.Matches(&c) {
-			matches = append(matches, i)
-		}
+	cards, iterErr := carddb.Candidates(store, func(summary *card.Card) bool { return query.MayMatch(q, summary) })
+	matches := query.Search(cards, q)
+	if err := iterErr(); err != nil {
+		log.Fatal(err)
 	}
-	slices.SortFunc(matches, func(a, b int) int { return cmp.Compare(cards[a].Name, cards[b].Name) })
 	elapsed := time.Since(start)
 
-	log.Printf("searched %d cards in %s", len(cards), elapsed.String())
+	log.Printf("searched %d cards in %s", store.Len(), elapsed.String())
+
+	if *facets {
+		f := query.NewFacets()
+		for i := range matches {
+			f.Add(matches[i].Card)
+		}
+		printFacets(f)
+	}
 
 	printMax = min(printMax, len(matches))
 	fmt.Printf("Showing %d/%d\n", printMax, len(matches))
 	for i := range printMax {
+		c := matches[i].Card
 		if *short {
-			fmt.Printf("%d.\t%s\n", i, cards[matches[i]].Name)
+			fmt.Printf("%d.\t%s\n", i, c.Name)
 		} else {
-			fmt.Printf("\t%s -- %s\n%s\n\n", cards[matches[i]].Name, cards[matches[i]].OracleID, strings.Join(cards[matches[i]].GetOracleText(), "\n"))
+			var costs []string
+			for _, f := range c.Faces() {
+				if f.ManaCost != "" {
+					costs = append(costs, f.ManaCost)
+				}
+			}
+			fmt.Printf("\t%s %s -- %s\n%s\n\n", c.Name, symbolTable.Render(strings.Join(costs, " // "), symbolFormat), c.OracleID,
+				symbolTable.Render(strings.Join(c.GetOracleText(), "\n"), symbolFormat))
 		}
 	}
 }
 
+func explainCmd(flags *flag.FlagSet, args []string) {
+	filter := flags.String("filter", "default", "the default filter to apply")
+	macros := flags.String("macros", "", "path to a JSON or TOML file of query macros")
+	sets := flags.String("sets", "", "path to a sets.json, required by block: and set release order queries such as set>=dom")
+	flags.Parse(args)
+	loadMacros(*macros)
+	loadSets(*sets)
+
+	const NARGS = 3
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+
+	cardsPath := flags.Arg(0)
+	queryString := flags.Arg(1)
+	name := flags.Arg(2)
+
+	q, err := query.ParseWithFilter(queryString, *filter)
+	if err != nil {
+		log.Fatalf("failed to parse query '%s': %s", queryString, err)
+	}
+
+	store, err := openStore(cardsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(store)
+
+	c, err := findCard(store, name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printExplanation(query.Explain(q, c), 0)
+}
+
+var errNoSuchCard = errors.New("no such card")
+
+// findCard returns the card with the given name, ignoring case
+func findCard(store carddb.CardStore, name string) (*card.Card, error) {
+	found := store.ByName(name)
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", errNoSuchCard, name)
+	}
+	return store.Get(found[0])
+}
+
+func relatedCmd(flags *flag.FlagSet, args []string) {
+	flags.Parse(args)
+
+	const NARGS = 2
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+
+	store, err := openStore(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(store)
+
+	c, err := findCard(store, flags.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var components []string
+	related := map[string][]card.RelatedCard{}
+	for _, part := range c.AllParts {
+		if part.Name == c.Name {
+			continue
+		}
+		if _, exists := related[part.Component]; !exists {
+			components = append(components, part.Component)
+		}
+		related[part.Component] = append(related[part.Component], part)
+	}
+
+	fmt.Printf("%s\n", c.Name)
+	for _, component := range components {
+		fmt.Printf("\t%s:\n", component)
+		for _, part := range related[component] {
+			fmt.Printf("\t\t%s -- %s\n", part.Name, part.TypeLine)
+		}
+	}
+}
+
+func setsCmd(flags *flag.FlagSet, args []string) {
+	fetchSets := flags.Bool("fetch", false, "fetch the sets from scryfall and save them to the given path")
+	block := flags.String("block", "", "only list the sets of this block code or name")
+	flags.Parse(args)
+
+	const NARGS = 1
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+	path := flags.Arg(0)
+
+	if *fetchSets {
+		if err := saveSets(path); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	sets, err := card.DecodeSets(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slices.SortStableFunc(sets, func(a, b card.Set) int { return cmp.Compare(a.ReleasedAt, b.ReleasedAt) })
+	for _, s := range sets {
+		blockName := ""
+		if s.Block != nil {
+			blockName = *s.Block
+		}
+		if *block != "" && !strings.EqualFold(blockName, *block) && (s.BlockCode == nil || !strings.EqualFold(*s.BlockCode, *block)) {
+			continue
+		}
+		fmt.Printf("%s\t%s\t%s (%s, %d cards)", s.ReleasedAt, s.Code, s.Name, s.SetType, s.CardCount)
+		if blockName != "" {
+			fmt.Printf(" -- %s", blockName)
+		}
+		fmt.Println()
+	}
+}
+
+// saveSets fetches every set from scryfall and writes them to path
+func saveSets(path string) error {
+	r, err := fetch.OpenSets()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", path, err)
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", path, err)
+	}
+	return nil
+}
+
+func validateCmd(flags *flag.FlagSet, args []string) {
+	flags.Parse(args)
+
+	const NARGS = 1
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	report, err := card.Validate(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	issues := report.Issues()
+	fmt.Printf("validated %d cards, found %d issues\n", report.Cards, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("\t%s\t%s", issue.Kind, issue.Path)
+		if issue.Detail != "" {
+			fmt.Printf(" (%s)", issue.Detail)
+		}
+		fmt.Printf("\t%d cards, ex. %s\n", issue.Count, strings.Join(issue.Examples, ", "))
+	}
+	if len(issues) > 0 {
+		os.Exit(1)
+	}
+}
+
+func diffCmd(flags *flag.FlagSet, args []string) {
+	asJSON := flags.Bool("json", false, "print the changes as JSON")
+	flags.Parse(args)
+
+	const NARGS = 2
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+
+	before, err := openStore(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(before)
+	after, err := openStore(flags.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(after)
+
+	changes, err := carddb.Diff(before, after)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(changes); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	markers := map[carddb.ChangeKind]string{carddb.Added: "+", carddb.Removed: "-", carddb.Changed: "~"}
+	for _, change := range changes {
+		fmt.Printf("%s %s\n", markers[change.Kind], change.Name)
+		for _, field := range change.Fields {
+			if field.Printing != "" {
+				fmt.Printf("\t%s (%s): %q -> %q\n", field.Field, field.Printing, field.Old, field.New)
+				continue
+			}
+			fmt.Printf("\t%s: %q -> %q\n", field.Field, field.Old, field.New)
+		}
+	}
+}
+
+// loadRulings reads the rulings at path, or fetches them if path is empty
+func loadRulings(path string) (card.Rulings, error) {
+	var r io.ReadCloser
+	var err error
+	if path == "" {
+		r, _, err = fetch.OpenBulkData("rulings")
+	} else {
+		r, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return card.DecodeRulings(r)
+}
+
+func rulingsCmd(flags *flag.FlagSet, args []string) {
+	flags.Parse(args)
+
+	const NARGS = 2
+	if flags.NArg() != NARGS {
+		fmt.Fprintf(flags.Output(), "please supply exactly %d arguments", NARGS)
+		flags.Usage()
+	}
+
+	store, err := openStore(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeStore(store)
+
+	c, err := findCard(store, flags.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%s\n", c.Name)
+	if len(c.Rulings) == 0 {
+		fmt.Println("\tno rulings, cards only have rulings if fetched with -rulings")
+	}
+	for _, r := range c.Rulings {
+		fmt.Printf("\t%s (%s): %s\n", r.PublishedAt, r.Source, r.Comment)
+	}
+}
+
+func printExplanation(e query.Explanation, depth int) {
+	verdict := "FAIL"
+	if e.Matched {
+		verdict = "PASS"
+	}
+	fmt.Printf("%s%s %s", strings.Repeat("  ", depth), verdict, e.Query)
+	if e.Field != "" {
+		fmt.Printf(" (%s", e.Field)
+		if e.Face != "" {
+			fmt.Printf(" of %s", e.Face)
+		}
+		fmt.Printf(" = %q)", e.Value)
+	}
+	fmt.Println()
+	for _, child := range e.Children {
+		printExplanation(child, depth+1)
+	}
+}
+
+// loadMacros registers the macros in path, if any
+func loadMacros(path string) {
+	if path == "" {
+		return
+	}
+	if err := query.LoadMacros(path); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadSets registers the sets in path, if any
+func loadSets(path string) {
+	if path == "" {
+		return
+	}
+	if err := query.LoadSets(path); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadSymbology returns the format named by format, and the symbols in path or the built in symbols if path is empty
+func loadSymbology(format, path string) (symbology.Format, symbology.Table) {
+	var f symbology.Format
+	if format == "auto" {
+		f = symbology.Text
+		if stat, err := os.Stdout.Stat(); err == nil && stat.Mode()&os.ModeCharDevice != 0 {
+			f = symbology.ANSI
+		}
+	} else {
+		var err error
+		if f, err = symbology.ParseFormat(format); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if path == "" {
+		return f, symbology.Default
+	}
+	r, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer r.Close()
+	table, err := symbology.Decode(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return f, table
+}
+
+// facetMax is the max amount of buckets printed per facet
+const facetMax = 10
+
+func printFacets(f query.Facets) {
+	legal := map[string]int{}
+	for format, count := range f.Legal {
+		legal[card.Format(format).DisplayName()] = count
+	}
+	facets := []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"color identity", f.ColorIdentity},
+		{"mana value", f.ManaValue},
+		{"type", f.Type},
+		{"rarity", f.Rarity},
+		{"set", f.Set},
+		{"legal", legal},
+	}
+	for _, facet := range facets {
+		fmt.Printf("%s:", facet.name)
+		counts := query.Sorted(facet.counts)
+		for _, c := range counts[:min(facetMax, len(counts))] {
+			fmt.Printf(" %s(%d)", c.Value, c.Count)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
 func serveCmd(flags *flag.FlagSet, args []string) {
+	filter := flags.String("filter", "default", "the default filter to apply")
+	macros := flags.String("macros", "", "path to a JSON or TOML file of query macros")
+	sets := flags.String("sets", "", "path to a sets.json, required by block: and set release order queries such as set>=dom")
 	flags.Parse(args)
+	loadMacros(*macros)
+	loadSets(*sets)
 	const NARGS = 2
 	if flags.NArg() != NARGS {
 		flags.Usage()
@@ -207,12 +754,17 @@ func serveCmd(flags *flag.FlagSet, args []string) {
 	cardsPath := flags.Arg(0)
 	serveAddr := flags.Arg(1)
 
-	cards, err := deserializeCards(cardsPath)
+	store, err := openStore(cardsPath)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer closeStore(store)
+
+	if _, err := query.NewFilter(*filter, nil); err != nil {
+		log.Fatal(err)
+	}
 
-	server := Server{cards}
+	server := Server{store, *filter}
 	if err := rpc.DefaultServer.Register(&server); err != nil {
 		log.Fatal(err)
 	}
@@ -226,40 +778,46 @@ func serveCmd(flags *flag.FlagSet, args []string) {
 }
 
 type Server struct {
-	cards []card.Card
+	store  carddb.CardStore
+	filter string
 }
 
 type QueryResponse struct {
-	Cards []card.Card
-	Error error
+	Cards  []card.Card
+	Facets query.Facets
+	Error  error
 }
 
 func (s *Server) Query(req string, resp *QueryResponse) error {
 	start := time.Now()
-	slog.Info("Recieved Request", "query", req, "cards", len(s.cards))
-	q, err := query.Parse(req, true)
+	slog.Info("Recieved Request", "query", req, "cards", s.store.Len())
+	q, err := query.ParseWithFilter(req, s.filter)
 	if err != nil {
 		*resp = QueryResponse{Error: err}
 		return nil
 	}
 
-	var matched []card.Card
-	for _, c := range s.cards {
-		if q.Matches(&c) {
-			matched = append(matched, c)
-		}
+	// only the cards that the columns of a card database can't rule out are decoded
+	cards, iterErr := carddb.Candidates(s.store, func(summary *card.Card) bool { return query.MayMatch(q, summary) })
+	matches := query.Search(cards, q)
+	if err := iterErr(); err != nil {
+		return err
+	}
+	matched := make([]card.Card, len(matches))
+	for i, m := range matches {
+		matched[i] = *m.Card
 	}
-	slices.SortFunc(matched, func(a, b card.Card) int { return cmp.Compare(a.Name, b.Name) })
 	elapsed := time.Since(start)
 	slog.Debug("handled request", "query", req, "matches", len(matched), "took", elapsed.String())
 
-	*resp = QueryResponse{Cards: matched}
+	*resp = QueryResponse{Cards: matched, Facets: query.CountFacets(matched)}
 	return nil
 }
 
 func queryCmd(flags *flag.FlagSet, args []string) {
 	maxArg := flags.Uint("max", 5, "set the max amount of cards to print")
 	short := flags.Bool("short", false, "show short output")
+	facets := flags.Bool("facets", false, "print a summary of the matches")
 	flags.Parse(args)
 	printMax := int(*maxArg)
 
@@ -289,6 +847,10 @@ func queryCmd(flags *flag.FlagSet, args []string) {
 	}
 	cards := resp.Cards
 
+	if *facets {
+		printFacets(resp.Facets)
+	}
+
 	printMax = min(printMax, len(cards))
 	fmt.Printf("Showing %d/%d\n", printMax, len(resp.Cards))
 	// slices.SortFunc(matches, func(a, b int) int { return cmp.Compare(cards[a].Name, cards[b].Name) })
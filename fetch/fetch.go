@@ -3,6 +3,8 @@ package fetch
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -30,8 +32,14 @@ type BulkDataEntry struct {
 	ContentEncoding string `json:"content_encoding"`
 }
 
+// Updated returns when the bulk data file was last updated
+func (e BulkDataEntry) Updated() (time.Time, error) {
+	return time.Parse(time.RFC3339, e.UpdatedAt)
+}
+
 const (
 	bulkDataURI                     = "https://api.scryfall.com/bulk-data"
+	setsURI                         = "https://api.scryfall.com/sets"
 	userAgent                       = "mtgBuilder"
 	requestsPerSecond time.Duration = 1
 )
@@ -87,20 +95,53 @@ func fetchBulkData() (BulkData, error) {
 
 // GetOracleCardsJSON fetches the latest available oracle-cards.json from scryfall's bulk data api
 func GetOracleCardsJSON() ([]byte, error) {
-	bulkData, err := fetchBulkData()
+	return GetBulkDataJSON("oracle_cards")
+}
+
+var ErrUnknownBulkData = errors.New("unknown bulk data type")
+
+// GetBulkDataJSON fetches the latest available bulk data file of the given type from scryfall's bulk data api.
+// all_cards contains every printing in every language, oracle_cards a single english printing of each card
+func GetBulkDataJSON(bulkType string) ([]byte, error) {
+	body, _, err := OpenBulkData(bulkType)
 	if err != nil {
 		return nil, err
 	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// OpenBulkData starts downloading the latest available bulk data file of the given type, see GetBulkDataJSON.
+// The file can be decoded while it downloads with card.NewDecoder. The caller must close the returned body
+func OpenBulkData(bulkType string) (io.ReadCloser, BulkDataEntry, error) {
+	bulkData, err := fetchBulkData()
+	if err != nil {
+		return nil, BulkDataEntry{}, err
+	}
 
-	i := slices.IndexFunc(bulkData.Data, func(e BulkDataEntry) bool { return e.Type == "oracle_cards" })
-	oracleCardsURI := bulkData.Data[i].DownloadURI
+	i := slices.IndexFunc(bulkData.Data, func(e BulkDataEntry) bool { return e.Type == bulkType })
+	if i == -1 {
+		return nil, BulkDataEntry{}, fmt.Errorf("%w: '%s'", ErrUnknownBulkData, bulkType)
+	}
+	entry := bulkData.Data[i]
 
-	oracleCards, err := scryfallGet(oracleCardsURI)
+	cards, err := scryfallGet(entry.DownloadURI)
 	if err != nil {
-		return nil, err
+		return nil, BulkDataEntry{}, err
 	}
-	defer oracleCards.Body.Close()
+	return cards.Body, entry, nil
+}
 
-	bytes, err := io.ReadAll(oracleCards.Body)
-	return bytes, err
+// OpenSets starts downloading every set from scryfall's sets endpoint. The list can be decoded with card.DecodeSets.
+// The caller must close the returned body
+func OpenSets() (io.ReadCloser, error) {
+	resp, err := scryfallGet(setsURI)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to fetch sets: %s", resp.Status)
+	}
+	return resp.Body, nil
 }
@@ -3,12 +3,12 @@ module mtgBuilder
 go 1.24.5
 
 require (
+	github.com/goccy/go-json v0.10.5
 	github.com/google/go-cmp v0.7.0
 	github.com/google/uuid v1.6.0
 )
 
 require (
-	github.com/goccy/go-json v0.10.5 // indirect
 	golang.org/x/mod v0.26.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/tools v0.35.0 // indirect
@@ -10,16 +10,23 @@ type Color struct {
 	Colors    card.Colors
 }
 
-func (q Color) Matches(c *card.Card) bool {
+// faceColors returns the colors of every one of faces
+func faceColors(faces []card.Face) card.Colors {
 	var colors card.Colors
-	if c.Colors != nil {
-		colors.Add(*c.Colors)
-	}
-	for _, face := range c.CardFaces {
+	for _, face := range faces {
 		if face.Colors != nil {
 			colors.Add(*face.Colors)
 		}
 	}
+	return colors
+}
+
+func (q Color) Matches(c *card.Card) bool {
+	return q.matchFaces(c, c.Faces())
+}
+
+func (q Color) matchFaces(c *card.Card, faces []card.Face) bool {
+	colors := faceColors(faces)
 
 	if q.Mulicolor {
 		return len(colors) > 1
@@ -0,0 +1,339 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// Explanation mirrors a node of a query tree and records whether it matched a card
+type Explanation struct {
+	Query    string        `json:"query"`
+	Matched  bool          `json:"matched"`
+	Face     string        `json:"face,omitempty"`
+	Field    string        `json:"field,omitempty"`
+	Value    string        `json:"value,omitempty"`
+	Children []Explanation `json:"children,omitempty"`
+}
+
+// explainer is implemented by queries that can report which face, field and value decided a match
+type explainer interface {
+	explain(c *card.Card) Explanation
+}
+
+// Explain evaluates q against c and returns a tree mirroring q with a verdict for every node
+func Explain(q Query, c *card.Card) Explanation {
+	if e, ok := q.(explainer); ok {
+		ex := e.explain(c)
+		ex.Query = describe(q)
+		switch q.(type) {
+		case Negation, Union, Intersection, Faces, Filter, Ordered:
+		default:
+			// the verdict of a leaf always comes from Matches so explanations never disagree with search results
+			ex.Matched = q.Matches(c)
+		}
+		return ex
+	}
+	return Explanation{Query: describe(q), Matched: q.Matches(c)}
+}
+
+// describe returns a short human readable representation of a query node
+func describe(q Query) string {
+	switch q := q.(type) {
+	case Negation:
+		return "not"
+	case Union:
+		return "any of"
+	case Faces:
+		return q.Mode.String()
+	case Filter:
+		return "filter:" + q.Name
+	case Ordered:
+		return "order:" + q.Order
+	case Intersection:
+		return "all of"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T%+v", q, q), "query.")
+}
+
+func (o Ordered) explain(c *card.Card) Explanation {
+	inner := Explain(o.Query, c)
+	return Explanation{Matched: inner.Matched, Children: []Explanation{inner}}
+}
+
+func (f Filter) explain(c *card.Card) Explanation {
+	return f.Exclude.explain(c)
+}
+
+func (n Negation) explain(c *card.Card) Explanation {
+	inner := Explain(n.Query, c)
+	return Explanation{Matched: !inner.Matched, Children: []Explanation{inner}}
+}
+
+func (u Union) explain(c *card.Card) Explanation {
+	var e Explanation
+	for _, q := range u.Queries {
+		child := Explain(q, c)
+		e.Matched = e.Matched || child.Matched
+		e.Children = append(e.Children, child)
+	}
+	return e
+}
+
+func (i Intersection) explain(c *card.Card) Explanation {
+	e := Explanation{Matched: true}
+	for _, q := range i.Queries {
+		child := Explain(q, c)
+		e.Matched = e.Matched && child.Matched
+		e.Children = append(e.Children, child)
+	}
+	return e
+}
+
+func (f Faces) explain(c *card.Card) Explanation {
+	faces := faceCards(c, c.Faces())
+	if f.Mode == FrontFace {
+		faces = faces[:1]
+	}
+	e := Explanation{Matched: f.Mode != AnyFace}
+	for i := range faces {
+		child := Explain(f.Query, &faces[i])
+		child.Face = faces[i].Name
+		if f.Mode == AnyFace {
+			e.Matched = e.Matched || child.Matched
+		} else {
+			e.Matched = e.Matched && child.Matched
+		}
+		e.Children = append(e.Children, child)
+	}
+	return e
+}
+
+// fieldValue is a single value of a field, along with the face it was read from
+type fieldValue struct {
+	Face  string
+	Value string
+}
+
+// faceValues collects the value of a field on each of faces. Faces are only named for multifaced cards
+func faceValues(faces []card.Face, value func(f *card.Face) *string) []fieldValue {
+	var values []fieldValue
+	for i := range faces {
+		if v := value(&faces[i]); v != nil {
+			var name string
+			if len(faces) > 1 {
+				name = faces[i].Name
+			}
+			values = append(values, fieldValue{name, *v})
+		}
+	}
+	return values
+}
+
+func nameValues(faces []card.Face) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string { return &f.Name })
+}
+
+func typeLineValues(faces []card.Face) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string { return &f.TypeLine })
+}
+
+// explainAny reports the first value satisfying pred, or the first value examined if none do
+func explainAny(field string, values []fieldValue, pred func(string) bool) Explanation {
+	for _, v := range values {
+		if pred(v.Value) {
+			return Explanation{Matched: true, Face: v.Face, Field: field, Value: v.Value}
+		}
+	}
+	e := Explanation{Field: field}
+	if len(values) > 0 {
+		e.Face = values[0].Face
+		e.Value = values[0].Value
+	}
+	return e
+}
+
+func (t Type) explain(c *card.Card) Explanation {
+	return explainAny("type_line", append(typeLineValues(c.Faces()), fieldValue{"", c.TypeLine}), func(s string) bool { return containsWords(s, t.Text) })
+}
+
+func (s Supertype) explain(c *card.Card) Explanation {
+	return explainAny("type_line", typeLineValues(c.Faces()), s.match)
+}
+
+func (ct CardType) explain(c *card.Card) Explanation {
+	return explainAny("type_line", typeLineValues(c.Faces()), ct.match)
+}
+
+func (s Subtype) explain(c *card.Card) Explanation {
+	return explainAny("type_line", typeLineValues(c.Faces()), s.match)
+}
+
+func (n Name) explain(c *card.Card) Explanation {
+	return explainAny("name", nameValues(c.Faces()), func(s string) bool { return strings.Contains(strings.ToLower(s), n.Name) })
+}
+
+func (n NameExact) explain(c *card.Card) Explanation {
+	return explainAny("name", append([]fieldValue{{"", c.Name}}, nameValues(c.Faces())...), func(s string) bool { return strings.ToLower(s) == n.Name })
+}
+
+func (n NameRegex) explain(c *card.Card) Explanation {
+	return explainAny("name", append(nameValues(c.Faces()), fieldValue{"", c.Name}), n.Re.MatchString)
+}
+
+func (o OracleText) explain(c *card.Card) Explanation {
+	return explainAny("oracle_text", oracleValues(c, c.Faces(), strings.Contains(o.Substr, SelfReference)), o.match)
+}
+
+func (o FullOracleText) explain(c *card.Card) Explanation {
+	return explainAny("oracle_text", oracleValues(c, c.Faces(), strings.Contains(o.Substr, SelfReference)), o.match)
+}
+
+func (o OracleTextRegex) explain(c *card.Card) Explanation {
+	return explainAny("oracle_text", oracleValues(c, c.Faces(), strings.Contains(o.Re.String(), SelfReference)), o.match)
+}
+
+func (o FullOracleTextRegex) explain(c *card.Card) Explanation {
+	return explainAny("oracle_text", oracleValues(c, c.Faces(), strings.Contains(o.Re.String(), SelfReference)), o.Re.MatchString)
+}
+
+func (w Word) explain(c *card.Card) Explanation {
+	faces := c.Faces()
+	for _, f := range relevanceFields {
+		if text := f.text(c, faces); strings.Contains(fold(text), w.Word) {
+			return Explanation{Matched: true, Field: f.Name, Value: text}
+		}
+	}
+	return Explanation{Field: "name", Value: c.Name}
+}
+
+func (a Artist) explain(c *card.Card) Explanation {
+	return explainAny("artist", artistValues(c.Faces()), a.match)
+}
+
+func (f FlavorText) explain(c *card.Card) Explanation {
+	return explainAny("flavor_text", flavorValues(c.Faces()), f.match)
+}
+
+func (f FlavorTextRegex) explain(c *card.Card) Explanation {
+	return explainAny("flavor_text", flavorValues(c.Faces()), f.Re.MatchString)
+}
+
+func (r Rulings) explain(c *card.Card) Explanation {
+	return explainAny("rulings", rulingValues(c), r.match)
+}
+
+func (r RulingsRegex) explain(c *card.Card) Explanation {
+	return explainAny("rulings", rulingValues(c), r.Re.MatchString)
+}
+
+func (w Watermark) explain(c *card.Card) Explanation {
+	return explainAny("watermark", watermarkValues(c.Faces()), w.match)
+}
+
+func (r Related) explain(c *card.Card) Explanation {
+	e := Explanation{Field: "all_parts"}
+	parts := r.parts(c)
+	for _, part := range parts {
+		if r.match(part) {
+			e.Value = part.Name
+			return e
+		}
+	}
+	if len(parts) > 0 {
+		e.Value = parts[0].Name
+	}
+	return e
+}
+
+func (p PrintedName) explain(c *card.Card) Explanation {
+	return explainAny("printed_name", p.values(c.Faces()), p.match)
+}
+
+func (p PrintedText) explain(c *card.Card) Explanation {
+	return explainAny("printed_text", p.values(c.Faces()), p.match)
+}
+
+func (p PrintedType) explain(c *card.Card) Explanation {
+	return explainAny("printed_type_line", p.values(c.Faces()), p.match)
+}
+
+func (l Lang) explain(c *card.Card) Explanation {
+	return Explanation{Field: "lang", Value: c.Lang}
+}
+
+// explainNumber reports the first value of a numeric field satisfying pred, or the first value if none do
+func explainNumber(c *card.Card, field string, pred func(float64) bool) Explanation {
+	values := numericFields[field](c, c.Faces())
+	e := Explanation{Field: field}
+	for _, v := range values {
+		if pred(v.Value) {
+			e.Face, e.Value = v.Face, strconv.FormatFloat(v.Value, 'f', -1, 64)
+			return e
+		}
+	}
+	if len(values) > 0 {
+		e.Face, e.Value = values[0].Face, strconv.FormatFloat(values[0].Value, 'f', -1, 64)
+	}
+	return e
+}
+
+func (n Number) explain(c *card.Card) Explanation {
+	return explainNumber(c, n.Field, n.match)
+}
+
+func (r NumberRange) explain(c *card.Card) Explanation {
+	return explainNumber(c, r.Field, r.match)
+}
+
+func (p NumberParity) explain(c *card.Card) Explanation {
+	return explainNumber(c, p.Field, p.match)
+}
+
+func (v VariableStat) explain(c *card.Card) Explanation {
+	return explainAny(v.Field, faceValues(c.Faces(), statFields[v.Field]), v.match)
+}
+
+func (q Color) explain(c *card.Card) Explanation {
+	colors := faceColors(c.Faces())
+	return Explanation{Matched: q.Matches(c), Field: "colors", Value: strings.Join(colors, "")}
+}
+
+func (q ColorIdentity) explain(c *card.Card) Explanation {
+	e := Explanation{Matched: q.Matches(c), Field: "color_identity"}
+	if c.ColorIdentity != nil {
+		e.Value = strings.Join(*c.ColorIdentity, "")
+	}
+	return e
+}
+
+func (f Format) explain(c *card.Card) Explanation {
+	status := c.Legalities.Status(f.Format)
+	return Explanation{Matched: f.Matches(c), Field: "legalities." + string(f.Format), Value: fmt.Sprintf("%s in %s", status, f.Format.DisplayName())}
+}
+
+func (s Set) explain(c *card.Card) Explanation {
+	return Explanation{Matched: s.Matches(c), Field: "set", Value: c.Set}
+}
+
+func (s SetRelease) explain(c *card.Card) Explanation {
+	return Explanation{Matched: s.Matches(c), Field: "released_at", Value: releasedAt(c)}
+}
+
+func (b Block) explain(c *card.Card) Explanation {
+	return Explanation{Matched: b.Matches(c), Field: "set", Value: c.Set}
+}
+
+func (s SetType) explain(c *card.Card) Explanation {
+	return Explanation{Matched: s.Matches(c), Field: "set_type", Value: c.SetType}
+}
+
+func (o OracleID) explain(c *card.Card) Explanation {
+	e := Explanation{Matched: o.Matches(c), Field: "oracle_id"}
+	if c.OracleID != nil {
+		e.Value = c.OracleID.String()
+	}
+	return e
+}
@@ -0,0 +1,96 @@
+package query_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func loadCard(t testing.TB, file string) card.Card {
+	content, err := os.ReadFile("../card/testdata/" + file)
+	if err != nil {
+		t.Fatalf("failed to read file %s: %s", file, err)
+	}
+	var c card.Card
+	if err := json.Unmarshal(content, &c); err != nil {
+		t.Fatalf("failed to unmarshal %s: %s", file, err)
+	}
+	return c
+}
+
+// mustParse parses q, failing the test if it isn't valid
+func mustParse(t testing.TB, q string, filtered bool) query.Query {
+	t.Helper()
+	parsed, err := query.Parse(q, filtered)
+	if err != nil {
+		t.Fatalf("failed to parse query %s: %s", q, err)
+	}
+	return parsed
+}
+
+// matchCase is a query that is expected to match a card or not. The card is loaded from file unless it is given
+type matchCase struct {
+	file     string
+	card     *card.Card
+	query    string
+	expected bool
+}
+
+// checkMatches matches the query of every case against its card, parsing with the default filter if filtered is set
+func checkMatches(t *testing.T, filtered bool, cases []matchCase) {
+	t.Helper()
+	for _, testcase := range cases {
+		c, name := testcase.card, testcase.file
+		if c == nil {
+			loaded := loadCard(t, testcase.file)
+			c = &loaded
+		}
+		if name == "" {
+			name = c.Name
+		}
+		if got := mustParse(t, testcase.query, filtered).Matches(c); got != testcase.expected {
+			t.Errorf("got %t, expected %t when matching %s on %s", got, testcase.expected, testcase.query, name)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	c := loadCard(t, "split.json")
+	got := query.Explain(mustParse(t, `o:enchantment t:creature`, false), &c)
+	if got.Matched || len(got.Children) != 2 {
+		t.Fatalf("expected a failing node with 2 children, got %+v", got)
+	}
+
+	oracle := got.Children[0]
+	if !oracle.Matched || oracle.Face != "Tear" || oracle.Field != "oracle_text" {
+		t.Errorf("expected oracle text to match on the Tear face, got %+v", oracle)
+	}
+
+	typ := got.Children[1]
+	if typ.Matched || typ.Field != "type_line" || typ.Face != "Wear" || typ.Value != "Instant" {
+		t.Errorf("expected type line to fail on the Instant type of the Wear face, got %+v", typ)
+	}
+}
+
+func TestExplainTypePart(t *testing.T) {
+	c := loadCard(t, "flip.json")
+	cases := []struct {
+		query   string
+		matched bool
+		face    string
+		value   string
+	}{
+		{"cardtype:enchantment", true, "Erayo's Essence", "Legendary Enchantment"},
+		{"subtype:monk", true, "Erayo, Soratami Ascendant", "Legendary Creature — Moonfolk Monk"},
+		{"supertype:snow", false, "Erayo, Soratami Ascendant", "Legendary Creature — Moonfolk Monk"},
+	}
+	for _, testcase := range cases {
+		got := query.Explain(mustParse(t, testcase.query, false), &c).Children[0]
+		if got.Matched != testcase.matched || got.Field != "type_line" || got.Face != testcase.face || got.Value != testcase.value {
+			t.Errorf("unexpected explanation of %s: %+v", testcase.query, got)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package query
+
+import "strings"
+
+// ResetSets empties the set catalog, so that tests registering sets don't affect each other
+func ResetSets() {
+	setsMu.Lock()
+	defer setsMu.Unlock()
+	clear(setCatalog)
+}
+
+// UnregisterFilter removes a filter added by RegisterFilter, so that tests registering filters don't affect each other
+func UnregisterFilter(name string) {
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	delete(filters, strings.ToLower(name))
+}
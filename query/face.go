@@ -0,0 +1,100 @@
+package query
+
+import (
+	"fmt"
+
+	"mtgBuilder/card"
+)
+
+type FaceMode int
+
+const (
+	AnyFace FaceMode = iota
+	FrontFace
+	AllFaces
+)
+
+func (m FaceMode) String() string {
+	switch m {
+	case AnyFace:
+		return "anyface"
+	case FrontFace:
+		return "front"
+	case AllFaces:
+		return "allfaces"
+	default:
+		panic(fmt.Sprintf("Invalid FaceMode: %d", int(m)))
+	}
+}
+
+var faceModes = map[string]FaceMode{
+	"anyface":  AnyFace,
+	"front":    FrontFace,
+	"allfaces": AllFaces,
+}
+
+// Faces matches Query against the individual faces of a card as selected by Mode
+type Faces struct {
+	Mode  FaceMode
+	Query Query
+}
+
+func (f Faces) Matches(c *card.Card) bool {
+	return f.matchFaces(c, c.Faces())
+}
+
+func (f Faces) matchFaces(c *card.Card, faces []card.Face) bool {
+	cards := faceCards(c, faces)
+	// each face card has the single face it was made from
+	switch f.Mode {
+	case AnyFace:
+		for i := range cards {
+			if matchFaces(f.Query, &cards[i], faces[i:i+1]) {
+				return true
+			}
+		}
+		return false
+	case FrontFace:
+		return matchFaces(f.Query, &cards[0], faces[:1])
+	case AllFaces:
+		for i := range cards {
+			if !matchFaces(f.Query, &cards[i], faces[i:i+1]) {
+				return false
+			}
+		}
+		return true
+	}
+	panic(fmt.Sprintf("Invalid FaceMode: %d", int(f.Mode)))
+}
+
+// faceCards returns a single faced card for every one of faces, the faces of c
+func faceCards(c *card.Card, faces []card.Face) []card.Card {
+	if len(c.CardFaces) == 0 {
+		return []card.Card{*c}
+	}
+	cards := make([]card.Card, len(faces))
+	for i, face := range faces {
+		f := *c
+		f.CardFaces = nil
+		f.Name = face.Name
+		f.ManaCost = &face.ManaCost
+		f.TypeLine = face.TypeLine
+		f.OracleText = &face.OracleText
+		f.Colors = face.Colors
+		f.Power = face.Power
+		f.Toughness = face.Toughness
+		f.Loyalty = face.Loyalty
+		f.Defense = face.Defense
+		f.ImageUris = face.ImageUris
+		f.Cmc = face.Cmc
+		f.OracleID = face.OracleID
+		f.Artist = face.Artist
+		f.FlavorText = face.FlavorText
+		f.Watermark = face.Watermark
+		f.PrintedName = face.PrintedName
+		f.PrintedText = face.PrintedText
+		f.PrintedTypeLine = face.PrintedTypeLine
+		cards[i] = f
+	}
+	return cards
+}
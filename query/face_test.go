@@ -0,0 +1,37 @@
+package query_test
+
+import "testing"
+
+func TestFaces(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{file: "mdf.json", query: "pow=2", expected: true},
+		{file: "mdf.json", query: "tou=4", expected: true},
+		{file: "mdf.json", query: "t:sorcery", expected: true},
+		{file: "mdf.json", query: "front:t:sorcery", expected: false},
+		{file: "mdf.json", query: "front:t:creature", expected: true},
+		{file: "mdf.json", query: "allfaces:t:legendary", expected: false},
+		{file: "mdf.json", query: "anyface:pow>=2", expected: true},
+		{file: "mdf.json", query: "allfaces:pow=2", expected: false},
+		{file: "mdf.json", query: "front:pip:b=2", expected: true},
+		{file: "mdf.json", query: "front:pip:r>=1", expected: false},
+		{file: "mdf.json", query: "anyface:pip:r>=1", expected: true},
+		{file: "flip.json", query: "pow=1", expected: true},
+		{file: "flip.json", query: "allfaces:t:legendary", expected: true},
+		{file: "flip.json", query: "allfaces:t:creature", expected: false},
+		{file: "flip.json", query: "front:name:essence", expected: false},
+		{file: "split.json", query: "front:name:wear", expected: true},
+		{file: "split.json", query: "front:name:tear", expected: false},
+		{file: "split.json", query: "o:enchantment", expected: true},
+		{file: "split.json", query: "front:o:enchantment", expected: false},
+		{file: "split.json", query: "allfaces:t:instant", expected: true},
+		{file: "split.json", query: "name:/^wear .. tear$/", expected: true},
+		{file: "split.json", query: "front:name:/^wear .. tear$/", expected: false},
+		{file: "mdf.json", query: `t:"warlock sorcery"`, expected: true},
+		{file: "mdf.json", query: `front:t:"warlock sorcery"`, expected: false},
+		{file: "reversible.json", query: "t:enchantment", expected: true},
+		{file: "reversible.json", query: "allfaces:t:enchantment", expected: true},
+		{file: "reversible.json", query: "front:t:creature", expected: false},
+		{file: "nissa.json", query: "front:pow=3", expected: true},
+		{file: "nissa.json", query: "allfaces:t:elf", expected: true},
+	})
+}
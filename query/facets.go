@@ -0,0 +1,107 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// Facets counts how many cards of a result set fall into each refinement bucket
+type Facets struct {
+	ColorIdentity map[string]int `json:"color_identity"`
+	ManaValue     map[string]int `json:"mana_value"`
+	Type          map[string]int `json:"type"`
+	Rarity        map[string]int `json:"rarity"`
+	Set           map[string]int `json:"set"`
+	Legal         map[string]int `json:"legal"`
+}
+
+// FacetCount is a single bucket of a facet
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+func NewFacets() Facets {
+	return Facets{
+		ColorIdentity: map[string]int{},
+		ManaValue:     map[string]int{},
+		Type:          map[string]int{},
+		Rarity:        map[string]int{},
+		Set:           map[string]int{},
+		Legal:         map[string]int{},
+	}
+}
+
+// CountFacets returns the facets of a result set
+func CountFacets(cards []card.Card) Facets {
+	f := NewFacets()
+	for i := range cards {
+		f.Add(&cards[i])
+	}
+	return f
+}
+
+// Add counts c towards every facet it belongs to
+func (f *Facets) Add(c *card.Card) {
+	identity := "C"
+	if c.ColorIdentity != nil && len(*c.ColorIdentity) > 0 {
+		identity = colorKey(*c.ColorIdentity)
+	}
+	f.ColorIdentity[identity]++
+
+	if c.Cmc != nil {
+		f.ManaValue[strconv.FormatFloat(float64(*c.Cmc), 'f', -1, 32)]++
+	}
+
+	var types []string
+	for _, t := range c.TypeLines() {
+		for _, name := range t.Types {
+			name = strings.ToLower(name)
+			if !slices.Contains(types, name) {
+				types = append(types, name)
+			}
+		}
+	}
+	for _, t := range types {
+		f.Type[t]++
+	}
+
+	f.Rarity[c.Rarity]++
+	f.Set[c.Set]++
+
+	for format, status := range c.Legalities {
+		if status == card.Legal {
+			f.Legal[string(format)]++
+		}
+	}
+}
+
+// Sorted returns the buckets of a facet ordered by descending count
+func Sorted(facet map[string]int) []FacetCount {
+	counts := make([]FacetCount, 0, len(facet))
+	for v, n := range facet {
+		counts = append(counts, FacetCount{v, n})
+	}
+	slices.SortFunc(counts, func(a, b FacetCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Value, b.Value)
+	})
+	return counts
+}
+
+// colorKey returns colors as a string in WUBRG order
+func colorKey(colors card.Colors) string {
+	var b strings.Builder
+	for _, c := range []string{"W", "U", "B", "R", "G"} {
+		if slices.Contains(colors, c) {
+			b.WriteString(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package query_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestFacets(t *testing.T) {
+	cards := []card.Card{loadCard(t, "split.json"), loadCard(t, "mdf.json"), loadCard(t, "nissa.json")}
+	f := query.CountFacets(cards)
+
+	cases := []struct {
+		facet    string
+		counts   map[string]int
+		value    string
+		expected int
+	}{
+		{"color identity", f.ColorIdentity, "WR", 1},
+		{"color identity", f.ColorIdentity, "WBR", 1},
+		{"mana value", f.ManaValue, "4", 2},
+		{"type", f.Type, "instant", 1},
+		{"type", f.Type, "creature", 2},
+		{"type", f.Type, "sorcery", 1},
+		{"rarity", f.Rarity, "rare", 1},
+		{"set", f.Set, "stx", 1},
+		{"legal", f.Legal, "commander", 3},
+		{"legal", f.Legal, "modern", 2},
+	}
+	for _, c := range cases {
+		if got := c.counts[c.value]; got != c.expected {
+			t.Errorf("expected %s %s to have %d cards, got %d", c.facet, c.value, c.expected, got)
+		}
+	}
+
+	sorted := query.Sorted(f.Type)
+	if sorted[0].Value != "creature" {
+		t.Errorf("expected creature to be the largest type bucket, got %+v", sorted)
+	}
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"mtgBuilder/card"
+)
+
+// exclusions are the groups of cards a filter may hide, keyed by the name used in include: directives
+var exclusions = map[string][]Query{
+	"tokens":      {Type{"token"}},
+	"planechase":  {Type{"plane"}, Type{"phenomenon"}},
+	"schemes":     {Type{"scheme"}},
+	"vanguard":    {Type{"vanguard"}},
+	"memorabilia": {SetType{"memorabilia"}, SetType{"minigame"}, Set{"unk"}},
+	"funny":       {SetType{"funny"}},
+}
+
+// extras are the exclusions lifted by include:extras
+var extras = []string{"tokens", "planechase", "schemes", "vanguard", "memorabilia"}
+
+// filters maps the names of default filters to the exclusions they apply.
+// A filter is selected with the filter: directive or by ParseWithFilter
+var filters = map[string][]string{
+	"default": {"vanguard", "planechase", "schemes", "tokens", "memorabilia"},
+	"nofunny": {"funny"},
+	"none":    {},
+}
+
+// filtersMu guards filters, which may be registered while queries are parsed
+var filtersMu sync.RWMutex
+
+var (
+	ErrUnknownFilter    = errors.New("unknown filter")
+	ErrUnknownExclusion = errors.New("unknown exclusion")
+)
+
+// RegisterFilter adds or replaces a named default filter
+func RegisterFilter(name string, excluded ...string) error {
+	for _, e := range excluded {
+		if _, exists := exclusions[e]; !exists {
+			return fmt.Errorf("%w: '%s'", ErrUnknownExclusion, e)
+		}
+	}
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	filters[strings.ToLower(name)] = slices.Clone(excluded)
+	return nil
+}
+
+// Filter is a named default filter. It only matches cards that none of its exclusions match
+type Filter struct {
+	Name    string
+	Exclude Intersection
+}
+
+func (f Filter) Matches(c *card.Card) bool {
+	return f.Exclude.Matches(c)
+}
+
+func (f Filter) matchFaces(c *card.Card, faces []card.Face) bool {
+	return f.Exclude.matchFaces(c, faces)
+}
+
+// NewFilter builds the named filter, skipping the exclusions in include
+func NewFilter(name string, include []string) (Filter, error) {
+	filtersMu.RLock()
+	excluded, exists := filters[strings.ToLower(name)]
+	filtersMu.RUnlock()
+	if !exists {
+		return Filter{}, fmt.Errorf("%w: '%s'", ErrUnknownFilter, name)
+	}
+	var lifted []string
+	for _, i := range include {
+		i = strings.ToLower(i)
+		switch {
+		case i == "extras":
+			lifted = append(lifted, extras...)
+		case exclusions[i] != nil:
+			lifted = append(lifted, i)
+		default:
+			return Filter{}, fmt.Errorf("%w: '%s'", ErrUnknownExclusion, i)
+		}
+	}
+
+	f := Filter{Name: strings.ToLower(name)}
+	for _, e := range excluded {
+		if slices.Contains(lifted, e) {
+			continue
+		}
+		for _, q := range exclusions[e] {
+			f.Exclude.Queries = append(f.Exclude.Queries, Negation{q})
+		}
+	}
+	return f, nil
+}
+
+// DefaultFilter excludes cards that aren't usually wanted in search results
+var DefaultFilter = func() Filter {
+	f, err := NewFilter("default", nil)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}()
@@ -0,0 +1,50 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestFilter(t *testing.T) {
+	zombie := card.Card{Name: "Zombie", TypeLine: "Token Creature — Zombie", PrintFields: card.PrintFields{SetType: "token"}}
+	plane := card.Card{Name: "Tazeem", TypeLine: "Plane — Zendikar", PrintFields: card.PrintFields{SetType: "planechase"}}
+	funny := card.Card{Name: "Chicken Egg", TypeLine: "Creature — Egg", PrintFields: card.PrintFields{SetType: "funny"}}
+	bear := card.Card{Name: "Grizzly Bears", TypeLine: "Creature — Bear", PrintFields: card.PrintFields{SetType: "core"}}
+
+	checkMatches(t, true, []matchCase{
+		{card: &bear, query: "", expected: true},
+		{card: &zombie, query: "", expected: false},
+		{card: &zombie, query: "include:tokens", expected: true},
+		{card: &plane, query: "include:tokens", expected: false},
+		{card: &plane, query: "include:extras", expected: true},
+		{card: &funny, query: "", expected: true},
+		{card: &funny, query: "include:extras", expected: true},
+		{card: &funny, query: "include:funny", expected: true},
+		{card: &zombie, query: "include:funny include:tokens", expected: true},
+		{card: &plane, query: "filter:none", expected: true},
+		{card: &zombie, query: "filter:nofunny", expected: true},
+		{card: &funny, query: "filter:nofunny", expected: false},
+	})
+
+	if _, err := query.Parse("include:tokes", true); !errors.Is(err, query.ErrUnknownExclusion) {
+		t.Errorf("expected %s, got %v", query.ErrUnknownExclusion, err)
+	}
+	if _, err := query.Parse("filter:nope", true); !errors.Is(err, query.ErrUnknownFilter) {
+		t.Errorf("expected %s, got %v", query.ErrUnknownFilter, err)
+	}
+
+	if err := query.RegisterFilter("planechase", "tokens", "funny"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { query.UnregisterFilter("planechase") })
+	q, err := query.ParseWithFilter("", "planechase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Matches(&plane) || q.Matches(&zombie) {
+		t.Errorf("expected registered filter to only hide tokens and funny cards")
+	}
+}
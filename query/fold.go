@@ -0,0 +1,56 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// diacritics maps accented latin letters to their unaccented form
+var diacritics = func() map[rune]string {
+	groups := map[string]string{
+		"àáâãäåāăą":  "a",
+		"çćĉċč":      "c",
+		"ďđ":         "d",
+		"èéêëēĕėęě":  "e",
+		"ĝğġģ":       "g",
+		"ĥħ":         "h",
+		"ìíîïĩīĭįı":  "i",
+		"ĵ":          "j",
+		"ķ":          "k",
+		"ĺļľŀł":      "l",
+		"ñńņňŉ":      "n",
+		"òóôõöøōŏő":  "o",
+		"ŕŗř":        "r",
+		"śŝşšș":      "s",
+		"ţťŧț":       "t",
+		"ùúûüũūŭůűų": "u",
+		"ŵ":          "w",
+		"ýÿŷ":        "y",
+		"źżž":        "z",
+		"ß":          "ss",
+		"æ":          "ae",
+		"œ":          "oe",
+	}
+	m := map[rune]string{}
+	for accented, base := range groups {
+		for _, r := range accented {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// fold lowercases s and strips diacritics from latin letters so that "Éclair" and "eclair" compare equal
+func fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if base, exists := diacritics[r]; exists {
+			b.WriteString(base)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
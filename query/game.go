@@ -0,0 +1,51 @@
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+type Layout struct {
+	Name string
+}
+
+func (l Layout) Matches(c *card.Card) bool {
+	return strings.EqualFold(c.Layout, l.Name)
+}
+
+// Game matches cards whose printing is available in a game, one of paper, arena or mtgo
+type Game struct {
+	Name string
+}
+
+func (g Game) Matches(c *card.Card) bool {
+	return slices.ContainsFunc(c.Games, func(game string) bool { return strings.EqualFold(game, g.Name) })
+}
+
+// Available matches cards that can be played in a game, either by being printed in it or by having an id there
+type Available struct {
+	Game string
+}
+
+func (a Available) Matches(c *card.Card) bool {
+	switch a.Game {
+	case "arena":
+		if c.ArenaID != nil {
+			return true
+		}
+	case "mtgo":
+		if c.MtgoID != nil {
+			return true
+		}
+	}
+	return Game{a.Game}.Matches(c)
+}
+
+// Digital matches cards only released in a video game
+type Digital struct{}
+
+func (Digital) Matches(c *card.Card) bool {
+	return c.Digital
+}
@@ -0,0 +1,38 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestGame(t *testing.T) {
+	arenaOnly := card.Card{
+		CoreFields:  card.CoreFields{ArenaID: &[]int{1}[0], Layout: "normal"},
+		PrintFields: card.PrintFields{Digital: true},
+	}
+	checkMatches(t, false, []matchCase{
+		{file: "mdf.json", query: "layout:modal_dfc", expected: true},
+		{file: "mdf.json", query: "game:arena", expected: true},
+		{file: "mdf.json", query: "in:arena", expected: true},
+		{file: "nissa.json", query: "game:arena", expected: false},
+		{file: "nissa.json", query: "in:arena", expected: false},
+		{file: "nissa.json", query: "game:mtgo", expected: true},
+		{file: "reversible.json", query: "game:paper", expected: true},
+		{file: "reversible.json", query: "in:mtgo", expected: false},
+		{file: "split.json", query: "is:digital", expected: false},
+		{card: &arenaOnly, query: "in:arena", expected: true},
+		{card: &arenaOnly, query: "game:arena", expected: false},
+		{card: &arenaOnly, query: "is:digital", expected: true},
+		{card: &arenaOnly, query: "layout:normal", expected: true},
+	})
+
+	if _, err := query.Parse("game:shandalar", false); !errors.Is(err, query.ErrUnknownGame) {
+		t.Errorf("expected %s, got %v", query.ErrUnknownGame, err)
+	}
+	if _, err := query.Parse("is:nope", false); !errors.Is(err, query.ErrUnknownProperty) {
+		t.Errorf("expected %s, got %v", query.ErrUnknownProperty, err)
+	}
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// Lang matches printings in a language, "any" matches every language
+type Lang struct {
+	Code string
+}
+
+func (l Lang) Matches(c *card.Card) bool {
+	return l.Code == "any" || strings.EqualFold(c.Lang, l.Code)
+}
+
+// printedValues returns the localized values of each of faces of a printing, falling back to the oracle values where a printing has none
+func printedValues(faces []card.Face, printed func(f *card.Face) *string, oracle func(f *card.Face) *string) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string {
+		if v := printed(f); v != nil {
+			return v
+		}
+		return oracle(f)
+	})
+}
+
+// PrintedName matches the localized name of a printing, ignoring case and diacritics
+type PrintedName struct {
+	Substr string
+}
+
+func (p PrintedName) values(faces []card.Face) []fieldValue {
+	return printedValues(faces,
+		func(f *card.Face) *string { return f.PrintedName },
+		func(f *card.Face) *string { return &f.Name })
+}
+
+func (p PrintedName) match(name string) bool {
+	return strings.Contains(fold(name), p.Substr)
+}
+
+func (p PrintedName) Matches(c *card.Card) bool {
+	return p.matchFaces(c, c.Faces())
+}
+
+func (p PrintedName) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(p.values(faces), p.match)
+}
+
+// PrintedText matches the localized text of a printing, ignoring case and diacritics
+type PrintedText struct {
+	Substr string
+}
+
+func (p PrintedText) values(faces []card.Face) []fieldValue {
+	return printedValues(faces,
+		func(f *card.Face) *string { return f.PrintedText },
+		func(f *card.Face) *string { return &f.OracleText })
+}
+
+func (p PrintedText) match(text string) bool {
+	return strings.Contains(fold(text), p.Substr)
+}
+
+func (p PrintedText) Matches(c *card.Card) bool {
+	return p.matchFaces(c, c.Faces())
+}
+
+func (p PrintedText) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(p.values(faces), p.match)
+}
+
+// PrintedType matches the localized type line of a printing, ignoring case and diacritics
+type PrintedType struct {
+	Substr string
+}
+
+func (p PrintedType) values(faces []card.Face) []fieldValue {
+	return printedValues(faces,
+		func(f *card.Face) *string { return f.PrintedTypeLine },
+		func(f *card.Face) *string { return &f.TypeLine })
+}
+
+func (p PrintedType) match(typeLine string) bool {
+	return strings.Contains(fold(typeLine), p.Substr)
+}
+
+func (p PrintedType) Matches(c *card.Card) bool {
+	return p.matchFaces(c, c.Faces())
+}
+
+func (p PrintedType) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(p.values(faces), p.match)
+}
@@ -0,0 +1,41 @@
+package query_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+)
+
+func TestLang(t *testing.T) {
+	german := card.Card{
+		CoreFields: card.CoreFields{Lang: "de"},
+		Name:       "Goblin Guide",
+		TypeLine:   "Creature — Goblin Scout",
+		PrintFields: card.PrintFields{
+			PrintedName:     &[]string{"Goblin-Späher"}[0],
+			PrintedText:     &[]string{"Eile\nImmer wenn der Goblin-Späher angreift, deckt der verteidigende Spieler die oberste Karte seiner Bibliothek auf."}[0],
+			PrintedTypeLine: &[]string{"Kreatur — Goblin, Späher"}[0],
+		},
+	}
+	english := card.Card{
+		CoreFields: card.CoreFields{Lang: "en"},
+		Name:       "Goblin Guide",
+		TypeLine:   "Creature — Goblin Scout",
+		OracleText: &[]string{"Haste"}[0],
+	}
+	checkMatches(t, false, []matchCase{
+		{card: &german, query: "lang:de", expected: true},
+		{card: &german, query: "lang:DE", expected: true},
+		{card: &german, query: "lang:en", expected: false},
+		{card: &german, query: "lang:any", expected: true},
+		{card: &german, query: "pname:spaher", expected: true},
+		{card: &german, query: "pname:späher lang:de", expected: true},
+		{card: &german, query: `pname:"GOBLIN-SPÄHER"`, expected: true},
+		{card: &german, query: `ptext:"immer wenn"`, expected: true},
+		{card: &german, query: "ptype:kreatur", expected: true},
+		{card: &german, query: "pname:guide", expected: false},
+		{card: &english, query: "pname:guide", expected: true},
+		{card: &english, query: "ptext:haste", expected: true},
+		{card: &english, query: "language:en", expected: true},
+	})
+}
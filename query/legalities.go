@@ -1,10 +1,26 @@
 package query
 
-import "mtgBuilder/card"
+import (
+	"errors"
 
+	"mtgBuilder/card"
+)
+
+// Format matches cards with the Expected legality in a format, ex. legal:modern or banned:commander
 type Format struct {
-	Format   string
-	Expected string
+	Format   card.Format
+	Expected card.LegalityStatus
+}
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// legalityFields are the statuses matched by each legality field
+var legalityFields = map[string]card.LegalityStatus{
+	"format":     card.Legal,
+	"legal":      card.Legal,
+	"banned":     card.Banned,
+	"restricted": card.Restricted,
+	"notlegal":   card.NotLegal,
 }
 
 var formatAliases = map[string]string{
@@ -33,6 +49,5 @@ var formatAliases = map[string]string{
 }
 
 func (f Format) Matches(c *card.Card) bool {
-	status := c.Legalities[f.Format]
-	return status == f.Expected
+	return c.Legalities.Status(f.Format) == f.Expected
 }
@@ -0,0 +1,51 @@
+package query_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestLegalities(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{file: "nissa.json", query: "f:commander", expected: true},
+		{file: "nissa.json", query: "legal:edh", expected: true},
+		{file: "nissa.json", query: "f:m", expected: false},
+		{file: "nissa.json", query: "notlegal:modern", expected: true},
+		{file: "nissa.json", query: `legal:"Duel Commander"`, expected: true},
+		{file: "split.json", query: "legal:MODERN", expected: true},
+		{file: "split.json", query: "banned:modern", expected: false},
+		{file: "split.json", query: "restricted:vintage", expected: false},
+		{file: "split.json", query: "notlegal:pauper", expected: true},
+	})
+
+	restricted := loadCard(t, "split.json")
+	restricted.Legalities = card.Legalities{"vintage": card.Restricted}
+	for queryLine, expected := range map[string]bool{"restricted:vintage": true, "legal:vintage": false, "notlegal:legacy": true} {
+		q := mustParse(t, queryLine, false)
+		if got := q.Matches(&restricted); got != expected {
+			t.Errorf("got %t, expected %t when matching %s", got, expected, queryLine)
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	for _, q := range []string{"f:comander", "banned:xyz", "notlegal:standrd"} {
+		if _, err := query.Parse(q, false); !errors.Is(err, query.ErrUnknownFormat) {
+			t.Errorf("%s: expected ErrUnknownFormat, got %v", q, err)
+		} else if !strings.Contains(err.Error(), "commander") {
+			t.Errorf("%s: expected the error to list the known formats, got %s", q, err)
+		}
+	}
+}
+
+func TestExplainFormat(t *testing.T) {
+	c := loadCard(t, "nissa.json")
+	q := mustParse(t, "legal:duel", false)
+	if e := query.Explain(q, &c).Children[0]; e.Value != "legal in Duel Commander" {
+		t.Errorf("expected the format's display name in the explanation, got %+v", e)
+	}
+}
@@ -0,0 +1,181 @@
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Macro is a named query fragment, referenced in queries as $name
+type Macro struct {
+	Name     string
+	Fragment string
+	// Source describes where the macro was defined, such as a file path
+	Source string
+}
+
+// macros holds the macros expanded by Parse, keyed by lowercased name
+var macros = map[string]Macro{}
+
+// macrosMu guards macros, which may be registered while queries are parsed, such as by the web ui
+var macrosMu sync.RWMutex
+
+var (
+	ErrUnknownMacro       = errors.New("unknown macro")
+	ErrMacroCycle         = errors.New("macro cycle")
+	ErrInvalidMacroName   = errors.New("invalid macro name")
+	ErrInvalidMacroFile   = errors.New("invalid macro file")
+	ErrUnknownMacroFormat = errors.New("unknown macro file format")
+)
+
+// RegisterMacro adds or replaces a macro. source describes where it was defined
+func RegisterMacro(name, fragment, source string) error {
+	runes := []rune(name)
+	if len(runes) == 0 || slices.ContainsFunc(runes, func(r rune) bool { return !isLiteralRune(r) }) {
+		return fmt.Errorf("%w: '%s' from %s", ErrInvalidMacroName, name, source)
+	}
+	macrosMu.Lock()
+	defer macrosMu.Unlock()
+	macros[strings.ToLower(name)] = Macro{name, fragment, source}
+	return nil
+}
+
+// lookupMacro finds a macro by its lowercased name
+func lookupMacro(name string) (Macro, bool) {
+	macrosMu.RLock()
+	defer macrosMu.RUnlock()
+	m, exists := macros[name]
+	return m, exists
+}
+
+// LoadMacros registers the macros defined in a JSON or TOML file.
+// Both formats map macro names to query fragments, ex. {"budgetgolgari": "f:commander id<=bg"} or budgetgolgari = "f:commander id<=bg"
+func LoadMacros(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var fragments map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(content, &fragments); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidMacroFile, path, err)
+		}
+	case ".toml":
+		fragments, err = parseTOMLMacros(content)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidMacroFile, path, err)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownMacroFormat, path)
+	}
+	for name, fragment := range fragments {
+		if err := RegisterMacro(name, fragment, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTOMLMacros parses the subset of TOML used by macro files: comments and `name = "fragment"` pairs
+func parseTOMLMacros(content []byte) (map[string]string, error) {
+	macros := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(text, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		key = strings.TrimSpace(key)
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			key = unquoted
+		}
+		value = strings.TrimSpace(value)
+		if v, err := strconv.QuotedPrefix(value); err == nil {
+			rest := strings.TrimSpace(value[len(v):])
+			if rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: unexpected '%s' after value", line, rest)
+			}
+			value, _ = strconv.Unquote(v)
+		} else if len(value) >= 2 && value[0] == '\'' && strings.Contains(value[1:], "'") {
+			// literal strings can't contain escapes
+			value = value[1 : 1+strings.Index(value[1:], "'")]
+		} else {
+			return nil, fmt.Errorf("line %d: expected a string value", line)
+		}
+		macros[key] = value
+	}
+	return macros, scanner.Err()
+}
+
+// expandMacros replaces every $name in queryline with the fragment of the named macro.
+// stack holds the macros currently being expanded and is used to detect cycles
+func expandMacros(queryline string, stack []string) (string, error) {
+	tokens, err := scan(queryline)
+	if err != nil {
+		return "", err
+	}
+	runes := []rune(queryline)
+	var b strings.Builder
+	last := 0
+	for _, t := range tokens {
+		if t.Type != MacroRef {
+			continue
+		}
+		name := strings.ToLower(string(runes[t.Start+1 : t.End]))
+		m, exists := lookupMacro(name)
+		if !exists {
+			return "", fmt.Errorf("%w: '$%s'", ErrUnknownMacro, name)
+		}
+		if slices.Contains(stack, name) {
+			chain := "$" + strings.Join(append(stack, name), " -> $")
+			return "", fmt.Errorf("%w: %s (from %s)", ErrMacroCycle, chain, m.Source)
+		}
+		expanded, err := expandMacros(m.Fragment, append(stack, name))
+		if err == nil {
+			err = validateFragment(expanded)
+		}
+		if err != nil {
+			return "", fmt.Errorf("in macro $%s (from %s): %w", m.Name, m.Source, err)
+		}
+		b.WriteString(string(runes[last:t.Start]))
+		b.WriteString(" " + expanded + " ")
+		last = t.End
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String(), nil
+}
+
+// validateFragment checks that an expanded macro parses on its own
+func validateFragment(fragment string) error {
+	tokens, err := scan(fragment)
+	if err != nil {
+		return err
+	}
+	inequalities, err := groupTokens([]rune(fragment), tokens)
+	if err != nil {
+		return err
+	}
+	for _, ineq := range inequalities {
+		switch strings.ToLower(ineq.Left) {
+		case "filter", "include", "order", "direction", "dir":
+			continue
+		}
+		if _, err := parseInequality(ineq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
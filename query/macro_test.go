@@ -0,0 +1,62 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandMacros(t *testing.T) {
+	clear(macros)
+	t.Cleanup(func() { clear(macros) })
+
+	if err := LoadMacros("testdata/macros.toml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadMacros("testdata/macros.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Parse("$elves $Cheap", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Parse("t:elf f:commander id<=bg mv<=2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+
+	if _, err := Parse(`o:"$elves"`, false); err != nil {
+		t.Errorf("expected macros in quotes to be left alone, got %s", err)
+	}
+
+	if _, err := Parse("$missing", false); !errors.Is(err, ErrUnknownMacro) {
+		t.Errorf("expected %s, got %v", ErrUnknownMacro, err)
+	}
+
+	if err := RegisterMacro("a", "t:elf $b", "test a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterMacro("b", "$a", "test b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse("$a", false); !errors.Is(err, ErrMacroCycle) {
+		t.Errorf("expected %s, got %v", ErrMacroCycle, err)
+	}
+
+	if err := RegisterMacro("broken", "nope:1", "test broken"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Parse("$broken", false)
+	if !errors.Is(err, ErrUnknownField) || !strings.Contains(err.Error(), "test broken") {
+		t.Errorf("expected error pointing to the macro source, got %v", err)
+	}
+
+	if err := RegisterMacro("not valid", "t:elf", "test"); !errors.Is(err, ErrInvalidMacroName) {
+		t.Errorf("expected %s, got %v", ErrInvalidMacroName, err)
+	}
+}
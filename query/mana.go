@@ -1,41 +1,79 @@
 package query
 
 import (
-	"fmt"
-	"log/slog"
+	"slices"
 
 	"mtgBuilder/card"
 )
 
+// Mana matches cards with a face whose mana cost contains at least the symbols of Cost
 type Mana struct {
-	Colors card.Colors
+	Cost card.ManaCost
+}
+
+// manaCosts parses the mana costs of faces. Unparseable costs are skipped
+func manaCosts(faces []card.Face) []card.ManaCost {
+	var parsed []card.ManaCost
+	for _, face := range faces {
+		if m, err := card.ParseManaCost(face.ManaCost); err == nil {
+			parsed = append(parsed, m)
+		}
+	}
+	return parsed
 }
 
 func (m Mana) Matches(c *card.Card) bool {
-	panic("todo")
+	return m.matchFaces(c, c.Faces())
 }
 
-type Manavalue struct {
+func (m Mana) matchFaces(c *card.Card, faces []card.Face) bool {
+	for _, cost := range manaCosts(faces) {
+		remaining := slices.Clone(cost)
+		found := true
+		for _, s := range m.Cost {
+			i := slices.Index(remaining, s)
+			if i == -1 {
+				found = false
+				break
+			}
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// Pips compares the number of colored mana symbols in a face's mana cost
+type Pips struct {
 	Relationship relationship
-	Value        float32
+	Value        int
 }
 
-func (m Manavalue) Matches(c *card.Card) bool {
-	if c.Cmc == nil {
-		return false
-	}
-	slog.Debug("Manavalue Matching", "value", m.Value, "cmc", *c.Cmc, "name", c.Name)
-	switch m.Relationship {
-	case Less:
-		return m.Value < *c.Cmc
-	case LessEqual:
-		return m.Value <= *c.Cmc
-	case Equal, Colon:
-		return m.Value == *c.Cmc
-	case GreaterEqual:
-		return m.Value >= *c.Cmc
-	case Greater:
-		return m.Value > *c.Cmc
-	}
-	panic(fmt.Sprintf("Invalid relationship: %+v", m.Relationship))
+func (p Pips) Matches(c *card.Card) bool {
+	return p.matchFaces(c, c.Faces())
+}
+
+func (p Pips) matchFaces(c *card.Card, faces []card.Face) bool {
+	return slices.ContainsFunc(manaCosts(faces), func(m card.ManaCost) bool {
+		return fieldCompare(m.Pips(), p.Relationship, p.Value)
+	})
+}
+
+// Devotion compares the devotion of a face's mana cost to Colors. Hybrid symbols count toward each of their colors
+type Devotion struct {
+	Colors       card.Colors
+	Relationship relationship
+	Value        int
+}
+
+func (d Devotion) Matches(c *card.Card) bool {
+	return d.matchFaces(c, c.Faces())
+}
+
+func (d Devotion) matchFaces(c *card.Card, faces []card.Face) bool {
+	return slices.ContainsFunc(manaCosts(faces), func(m card.ManaCost) bool {
+		return fieldCompare(m.Devotion(d.Colors), d.Relationship, d.Value)
+	})
 }
@@ -0,0 +1,35 @@
+package query_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+)
+
+func TestMana(t *testing.T) {
+	gray := card.Card{Name: "Gray Merchant of Asphodel", ManaCost: &[]string{"{3}{B}{B}"}[0]}
+	hybrid := card.Card{Name: "Kitchen Finks", ManaCost: &[]string{"{1}{G/W}{G/W}"}[0]}
+	checkMatches(t, false, []matchCase{
+		{card: &gray, query: "pips>=2", expected: true},
+		{card: &gray, query: "pips>2", expected: false},
+		{card: &gray, query: "devotion:bb", expected: true},
+		{card: &gray, query: "devotion:bbb", expected: false},
+		{card: &gray, query: "devotion={b}{b}", expected: true},
+		{card: &gray, query: "pip:b>=2", expected: true},
+		{card: &gray, query: "pip:g>=1", expected: false},
+		{card: &gray, query: "m:bb", expected: true},
+		{card: &gray, query: "m:{3}{B}", expected: true},
+		{card: &gray, query: "m:bbb", expected: false},
+		{card: &hybrid, query: "pip:g=2", expected: true},
+		{card: &hybrid, query: "pip:w=2", expected: true},
+		{card: &hybrid, query: "devotion:{g/w}{g/w}", expected: true},
+		{card: &hybrid, query: "devotion:gww", expected: false},
+		{card: &hybrid, query: "m:{G/W}", expected: true},
+		{card: &hybrid, query: "m:{W/G}", expected: true},
+		{card: &hybrid, query: "pips=2", expected: true},
+		{file: "split.json", query: "pip:r=1", expected: true},
+		{file: "split.json", query: "devotion:rw", expected: false},
+		{file: "mdf.json", query: "devotion:bb", expected: true},
+		{file: "mdf.json", query: "m:{6}", expected: true},
+	})
+}
@@ -12,15 +12,11 @@ type Name struct {
 }
 
 func (n Name) Matches(c *card.Card) bool {
-	if strings.Contains(strings.ToLower(c.Name), n.Name) {
-		return true
-	}
-	for _, face := range c.CardFaces {
-		if strings.Contains(strings.ToLower(face.Name), n.Name) {
-			return true
-		}
-	}
-	return false
+	return n.matchFaces(c, c.Faces())
+}
+
+func (n Name) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(nameValues(faces), func(name string) bool { return strings.Contains(strings.ToLower(name), n.Name) })
 }
 
 type NameExact struct {
@@ -28,15 +24,15 @@ type NameExact struct {
 }
 
 func (n NameExact) Matches(c *card.Card) bool {
+	return n.matchFaces(c, c.Faces())
+}
+
+func (n NameExact) matchFaces(c *card.Card, faces []card.Face) bool {
+	// the full name of a multifaced card matches as well as the name of each face
 	if strings.ToLower(c.Name) == n.Name {
 		return true
 	}
-	for _, face := range c.CardFaces {
-		if strings.ToLower(face.Name) == n.Name {
-			return true
-		}
-	}
-	return false
+	return anyValue(nameValues(faces), func(name string) bool { return strings.ToLower(name) == n.Name })
 }
 
 type NameRegex struct {
@@ -44,13 +40,10 @@ type NameRegex struct {
 }
 
 func (o NameRegex) Matches(c *card.Card) bool {
-	if o.Re.MatchString(c.Name) {
-		return true
-	}
-	for _, face := range c.CardFaces {
-		if o.Re.MatchString(face.Name) {
-			return true
-		}
-	}
-	return false
+	return o.matchFaces(c, c.Faces())
+}
+
+func (o NameRegex) matchFaces(c *card.Card, faces []card.Face) bool {
+	// like NameExact, the full name of a multifaced card matches as well as the name of each face
+	return o.Re.MatchString(c.Name) || anyValue(nameValues(faces), o.Re.MatchString)
 }
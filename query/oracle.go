@@ -2,73 +2,145 @@ package query
 
 import (
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"mtgBuilder/card"
 )
 
-type FullOracleText struct {
-	Substr string
+// SelfReference is substituted for a card's own name in oracle text when a query refers to it
+const SelfReference = "~"
+
+var cardnameRe = regexp.MustCompile(`(?i)cardname`)
+
+// normalizeSelfReference rewrites the legacy CARDNAME placeholder to SelfReference
+func normalizeSelfReference(s string) string {
+	return cardnameRe.ReplaceAllLiteralString(s, SelfReference)
 }
 
-func (o FullOracleText) Matches(c *card.Card) bool {
-	if c.OracleText != nil {
-		if strings.Contains(strings.ToLower(*c.OracleText), o.Substr) {
-			return true
-		}
+// selfNames returns the names a card or face may use to refer to itself, longest first.
+// Legendary cards may also refer to themselves by the part of their name before the first comma
+func selfNames(name string, legendary bool) []string {
+	names := []string{name}
+	if short, _, found := strings.Cut(name, ","); found && legendary {
+		names = append(names, short)
 	}
-	for _, face := range c.CardFaces {
-		if face.OracleText != nil {
-			if strings.Contains(strings.ToLower(*face.OracleText), o.Substr) {
-				return true
+	return names
+}
+
+// replaceSelf replaces every whole word occurrence of names in text with SelfReference
+func replaceSelf(text string, names []string) string {
+	slices.SortFunc(names, func(a, b string) int { return len(b) - len(a) })
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		var b strings.Builder
+		rest := text
+		for {
+			i := strings.Index(rest, name)
+			if i == -1 {
+				b.WriteString(rest)
+				break
 			}
+			before, _ := utf8.DecodeLastRuneInString(rest[:i])
+			after, _ := utf8.DecodeRuneInString(rest[i+len(name):])
+			if isWordRune(before) || isWordRune(after) {
+				b.WriteString(rest[:i+len(name)])
+			} else {
+				b.WriteString(rest[:i])
+				b.WriteString(SelfReference)
+			}
+			rest = rest[i+len(name):]
 		}
+		text = b.String()
 	}
-	return false
+	return text
 }
 
-type OracleText struct {
-	Substr string
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
 }
 
-func (o OracleText) Matches(c *card.Card) bool {
-	if c.OracleText != nil {
-		t := strings.ToLower(StripParens.ReplaceAllLiteralString(*c.OracleText, ""))
-		if strings.Contains(t, o.Substr) {
-			return true
+// oracleValues returns the oracle text of each of faces, the faces of c.
+// If selfRef is set, references to the card's own name are replaced with SelfReference
+func oracleValues(c *card.Card, faces []card.Face, selfRef bool) []fieldValue {
+	values := faceValues(faces, func(f *card.Face) *string { return &f.OracleText })
+	if !selfRef {
+		return values
+	}
+	legendary := anyValue(typeLineValues(faces), Supertype{"Legendary"}.match)
+	cardNames := selfNames(c.Name, legendary)
+	for _, face := range faces {
+		cardNames = append(cardNames, selfNames(face.Name, legendary)...)
+	}
+	for i, v := range values {
+		names := cardNames
+		if v.Face != "" {
+			names = selfNames(v.Face, legendary)
 		}
+		values[i].Value = replaceSelf(v.Value, names)
 	}
-	for _, face := range c.CardFaces {
-		if face.OracleText != nil {
-			t := strings.ToLower(StripParens.ReplaceAllLiteralString(*face.OracleText, ""))
-			if strings.Contains(t, o.Substr) {
-				return true
-			}
+	return values
+}
+
+func matchOracle(c *card.Card, faces []card.Face, selfRef bool, pred func(string) bool) bool {
+	for _, v := range oracleValues(c, faces, selfRef) {
+		if pred(v.Value) {
+			return true
 		}
 	}
 	return false
 }
 
+type FullOracleText struct {
+	Substr string
+}
+
+func (o FullOracleText) match(text string) bool {
+	return strings.Contains(strings.ToLower(text), o.Substr)
+}
+
+func (o FullOracleText) Matches(c *card.Card) bool {
+	return o.matchFaces(c, c.Faces())
+}
+
+func (o FullOracleText) matchFaces(c *card.Card, faces []card.Face) bool {
+	return matchOracle(c, faces, strings.Contains(o.Substr, SelfReference), o.match)
+}
+
+type OracleText struct {
+	Substr string
+}
+
+func (o OracleText) match(text string) bool {
+	return strings.Contains(strings.ToLower(StripParens.ReplaceAllLiteralString(text, "")), o.Substr)
+}
+
+func (o OracleText) Matches(c *card.Card) bool {
+	return o.matchFaces(c, c.Faces())
+}
+
+func (o OracleText) matchFaces(c *card.Card, faces []card.Face) bool {
+	return matchOracle(c, faces, strings.Contains(o.Substr, SelfReference), o.match)
+}
+
 type OracleTextRegex struct {
 	Re *regexp.Regexp
 }
 
+func (o OracleTextRegex) match(text string) bool {
+	return o.Re.MatchString(StripParens.ReplaceAllLiteralString(text, ""))
+}
+
 func (o OracleTextRegex) Matches(c *card.Card) bool {
-	if c.OracleText != nil {
-		t := StripParens.ReplaceAllLiteralString(*c.OracleText, "")
-		if o.Re.MatchString(t) {
-			return true
-		}
-	}
-	for _, face := range c.CardFaces {
-		if face.OracleText != nil {
-			t := StripParens.ReplaceAllLiteralString(*face.OracleText, "")
-			if o.Re.MatchString(t) {
-				return true
-			}
-		}
-	}
-	return false
+	return o.matchFaces(c, c.Faces())
+}
+
+func (o OracleTextRegex) matchFaces(c *card.Card, faces []card.Face) bool {
+	return matchOracle(c, faces, strings.Contains(o.Re.String(), SelfReference), o.match)
 }
 
 type FullOracleTextRegex struct {
@@ -76,19 +148,11 @@ type FullOracleTextRegex struct {
 }
 
 func (o FullOracleTextRegex) Matches(c *card.Card) bool {
-	if c.OracleText != nil {
-		if o.Re.MatchString(*c.OracleText) {
-			return true
-		}
-	}
-	for _, face := range c.CardFaces {
-		if face.OracleText != nil {
-			if o.Re.MatchString(*face.OracleText) {
-				return true
-			}
-		}
-	}
-	return false
+	return o.matchFaces(c, c.Faces())
+}
+
+func (o FullOracleTextRegex) matchFaces(c *card.Card, faces []card.Face) bool {
+	return matchOracle(c, faces, strings.Contains(o.Re.String(), SelfReference), o.Re.MatchString)
 }
 
 type Keyword struct {
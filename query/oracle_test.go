@@ -0,0 +1,36 @@
+package query_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+)
+
+func TestSelfReference(t *testing.T) {
+	atraxa := card.Card{
+		Name:       "Atraxa, Praetors' Voice",
+		TypeLine:   "Legendary Creature — Phyrexian Angel Horror",
+		OracleText: &[]string{"Flying, vigilance, deathtouch, lifelink\nAt the beginning of your end step, proliferate. Atraxa can't be Atraxatized."}[0],
+	}
+	atraxaNonLegendary := atraxa
+	atraxaNonLegendary.TypeLine = "Creature — Phyrexian Angel Horror"
+	wall := card.Card{
+		Name:       "Wall of Stone",
+		TypeLine:   "Creature — Wall",
+		OracleText: &[]string{"Defender\nWhen Wall of Stone enters, you gain 1 life."}[0],
+	}
+
+	checkMatches(t, false, []matchCase{
+		{card: &wall, query: `o:"when ~ enters"`, expected: true},
+		{card: &wall, query: `o:"when cardname enters"`, expected: true},
+		{card: &wall, query: `o:/^when ~ enters/`, expected: true},
+		{card: &wall, query: `fo:"wall of stone enters"`, expected: true},
+		{card: &atraxa, query: `o:"~ can't"`, expected: true},
+		{card: &atraxa, query: `o:"~ized"`, expected: false},
+		{card: &atraxaNonLegendary, query: `o:"~ can't"`, expected: false},
+		{file: "flip.json", query: `o:"flip ~."`, expected: true},
+		{file: "double_faced.json", query: `o:"transform ~."`, expected: true},
+		{file: "double_faced.json", query: `o:"~ deals 3 damage"`, expected: true},
+		{file: "split.json", query: `o:~`, expected: false},
+	})
+}
@@ -0,0 +1,138 @@
+package query
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// Ordered is a query that also requests an order for its results
+type Ordered struct {
+	Query      Query
+	Order      string
+	Descending bool
+	// Words are the bare words of the query, scored by the relevance order
+	Words []string
+}
+
+func (o Ordered) Matches(c *card.Card) bool {
+	return matchFaces(o.Query, c, c.Faces())
+}
+
+func (o Ordered) matchFaces(c *card.Card, faces []card.Face) bool {
+	return matchFaces(o.Query, c, faces)
+}
+
+var ErrUnknownOrder = errors.New("unknown order")
+
+// orders compare cards for each name accepted by order:
+var orders = map[string]func(a, b *card.Card) int{
+	"name": func(a, b *card.Card) int {
+		return cmp.Compare(a.Name, b.Name)
+	},
+	"edhrec": func(a, b *card.Card) int {
+		return compareRank(a.EdhrecRank, b.EdhrecRank)
+	},
+	"penny": func(a, b *card.Card) int {
+		return compareRank(a.PennyRank, b.PennyRank)
+	},
+	"manavalue": func(a, b *card.Card) int {
+		return cmp.Compare(deref(a.Cmc), deref(b.Cmc))
+	},
+}
+
+// ranks are the ranks compared by the rank orders. Unranked cards are ordered last in either direction
+var ranks = map[string]func(c *card.Card) *int{
+	"edhrec": func(c *card.Card) *int { return c.EdhrecRank },
+	"penny":  func(c *card.Card) *int { return c.PennyRank },
+}
+
+// relevanceOrder orders cards by how well they match the bare words of the query, best first
+const relevanceOrder = "relevance"
+
+var orderAliases = map[string]string{
+	"mv":  "manavalue",
+	"cmc": "manavalue",
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// compareRank orders ranked cards before unranked cards
+func compareRank(a, b *int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return cmp.Compare(*a, *b)
+}
+
+func newOrdered(q Query, order, direction string) (Ordered, error) {
+	order = strings.ToLower(order)
+	if expanded, exists := orderAliases[order]; exists {
+		order = expanded
+	}
+	if order == "" {
+		order = "name"
+	}
+	if _, exists := orders[order]; !exists && order != relevanceOrder {
+		return Ordered{}, fmt.Errorf("%w: '%s'", ErrUnknownOrder, order)
+	}
+	o := Ordered{Query: q, Order: order}
+	switch strings.ToLower(direction) {
+	case "", "asc":
+	case "desc":
+		o.Descending = true
+	default:
+		return Ordered{}, fmt.Errorf("%w: direction '%s'", ErrUnknownOrder, direction)
+	}
+	return o, nil
+}
+
+// Compare returns a function ordering cards as requested by q, by name if q doesn't request an order.
+// Ties are broken by name
+func Compare(q Query) func(a, b *card.Card) int {
+	return CompareIn(q, nil)
+}
+
+// CompareIn is Compare for results drawn from corpus. The relevance order weighs words by how rare they are in corpus,
+// which should be the cards being ordered
+func CompareIn(q Query, corpus iter.Seq[*card.Card]) func(a, b *card.Card) int {
+	o, ok := q.(Ordered)
+	if !ok {
+		return orders["name"]
+	}
+	compare := orders[o.Order]
+	if o.Order == relevanceOrder {
+		compare = newRelevance(o.Words, corpus).compare()
+	}
+	rank, ranked := ranks[o.Order]
+	return func(a, b *card.Card) int {
+		if ranked {
+			if ra, rb := rank(a), rank(b); (ra == nil) != (rb == nil) {
+				return compareRank(ra, rb)
+			}
+		}
+		c := compare(a, b)
+		if o.Descending {
+			c = -c
+		}
+		if c == 0 {
+			return cmp.Compare(a.Name, b.Name)
+		}
+		return c
+	}
+}
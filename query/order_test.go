@@ -0,0 +1,49 @@
+package query_test
+
+import (
+	"slices"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestRank(t *testing.T) {
+	cards := []card.Card{
+		{Name: "Sol Ring", EdhrecRank: &[]int{1}[0]},
+		{Name: "Arcane Signet", EdhrecRank: &[]int{3}[0], PennyRank: &[]int{800}[0]},
+		{Name: "Unranked"},
+		{Name: "Command Tower", EdhrecRank: &[]int{2}[0], PennyRank: &[]int{1200}[0]},
+	}
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{"edhrec<=2", []string{"Command Tower", "Sol Ring"}},
+		{"edhrec>1", []string{"Arcane Signet", "Command Tower"}},
+		{"penny<1000", []string{"Arcane Signet"}},
+		{"edhrec>=1 order:edhrec", []string{"Sol Ring", "Command Tower", "Arcane Signet"}},
+		{"order:edhrec", []string{"Sol Ring", "Command Tower", "Arcane Signet", "Unranked"}},
+		{"order:edhrec direction:desc", []string{"Arcane Signet", "Command Tower", "Sol Ring", "Unranked"}},
+		{"order:penny dir:desc", []string{"Command Tower", "Arcane Signet", "Sol Ring", "Unranked"}},
+		{"order:penny", []string{"Arcane Signet", "Command Tower", "Sol Ring", "Unranked"}},
+	}
+	for _, testcase := range cases {
+		q := mustParse(t, testcase.query, false)
+		var matched []card.Card
+		for _, c := range cards {
+			if q.Matches(&c) {
+				matched = append(matched, c)
+			}
+		}
+		compare := query.Compare(q)
+		slices.SortFunc(matched, func(a, b card.Card) int { return compare(&a, &b) })
+		var got []string
+		for _, c := range matched {
+			got = append(got, c.Name)
+		}
+		if !slices.Equal(got, testcase.expected) {
+			t.Errorf("expected %v, got %v for %s", testcase.expected, got, testcase.query)
+		}
+	}
+}
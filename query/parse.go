@@ -1,6 +1,8 @@
 package query
 
 import (
+	"strings"
+
 	"mtgBuilder/card"
 )
 
@@ -9,7 +11,11 @@ type Negation struct {
 }
 
 func (n Negation) Matches(c *card.Card) bool {
-	return !n.Query.Matches(c)
+	return n.matchFaces(c, c.Faces())
+}
+
+func (n Negation) matchFaces(c *card.Card, faces []card.Face) bool {
+	return !matchFaces(n.Query, c, faces)
 }
 
 type Union struct {
@@ -17,8 +23,12 @@ type Union struct {
 }
 
 func (u Union) Matches(c *card.Card) bool {
+	return u.matchFaces(c, c.Faces())
+}
+
+func (u Union) matchFaces(c *card.Card, faces []card.Face) bool {
 	for _, q := range u.Queries {
-		if q.Matches(c) {
+		if matchFaces(q, c, faces) {
 			return true
 		}
 	}
@@ -30,26 +40,35 @@ type Intersection struct {
 }
 
 func (i Intersection) Matches(c *card.Card) bool {
+	return i.matchFaces(c, c.Faces())
+}
+
+func (i Intersection) matchFaces(c *card.Card, faces []card.Face) bool {
 	for _, q := range i.Queries {
-		if !q.Matches(c) {
+		if !matchFaces(q, c, faces) {
 			return false
 		}
 	}
 	return true
 }
 
-var DefaultFilter = Intersection{[]Query{
-	Negation{Type{"vanguard"}},
-	Negation{Type{"plane"}},
-	Negation{Type{"scheme"}},
-	Negation{Type{"phenomenon"}},
-	Negation{Type{"token"}},
-	Negation{SetType{"memorabilia"}},
-	Negation{SetType{"minigame"}},
-	Negation{Set{"unk"}},
-}}
-
+// Parse parses a query line. If withDefault is set the default filter is applied
 func Parse(queryline string, withDefault bool) (Query, error) {
+	filter := "none"
+	if withDefault {
+		filter = "default"
+	}
+	return ParseWithFilter(queryline, filter)
+}
+
+// ParseWithFilter parses a query line, expanding macros and applying the named filter unless the query selects another with a filter: directive.
+// Exclusions of the filter may be lifted with include: directives and results may be ordered with order: and direction:.
+// order:relevance searches bare words across name, type line and oracle text and orders results by how well they match
+func ParseWithFilter(queryline string, filter string) (Query, error) {
+	queryline, err := expandMacros(queryline, nil)
+	if err != nil {
+		return nil, err
+	}
 	runes := []rune(queryline)
 	tokens, err := scan(queryline)
 	if err != nil {
@@ -60,16 +79,55 @@ func Parse(queryline string, withDefault bool) (Query, error) {
 		return nil, err
 	}
 
-	var queries []Query
+	var include []string
+	var order, direction string
+	var rest []Inequality
 	for _, inequality := range inequalities {
+		switch strings.ToLower(inequality.Left) {
+		case "filter":
+			filter = unquote(inequality.Right)
+		case "include":
+			include = append(include, unquote(inequality.Right))
+		case "order":
+			order = unquote(inequality.Right)
+		case "direction", "dir":
+			direction = unquote(inequality.Right)
+		default:
+			rest = append(rest, inequality)
+		}
+	}
+
+	relevant := strings.ToLower(order) == relevanceOrder
+	var queries []Query
+	var words []string
+	for _, inequality := range rest {
+		if inequality.Left == "" && relevant {
+			// bare words are searched across name, type line and oracle text when ordered by relevance
+			word := unquote(inequality.Right)
+			words = append(words, word)
+			queries = append(queries, Word{fold(word)})
+			continue
+		}
 		query, err := parseInequality(inequality)
 		if err != nil {
 			return nil, err
 		}
 		queries = append(queries, query)
 	}
-	if withDefault {
-		queries = append(queries, DefaultFilter)
+	f, err := NewFilter(filter, include)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Exclude.Queries) > 0 {
+		queries = append(queries, f)
+	}
+	if order != "" || direction != "" {
+		o, err := newOrdered(Intersection{queries}, order, direction)
+		if err != nil {
+			return nil, err
+		}
+		o.Words = words
+		return o, nil
 	}
 	return Intersection{queries}, nil
 }
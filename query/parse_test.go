@@ -5,12 +5,35 @@ import (
 	"regexp"
 	"slices"
 	"testing"
+
+	"mtgBuilder/card"
 )
 
 func TestGrouping(t *testing.T) {
 	cases := map[string][]Inequality{
 		"f:c":  {{"f", Colon, "c"}},
 		"!cow": {{"!name", Equal, "cow"}},
+		"front:t:creature pow>=2": {
+			{"front:t", Colon, "creature"},
+			{"pow", GreaterEqual, "2"},
+		},
+		"front:pip:g>=1 anyface:pip:r=2": {
+			{"front:pip:g", GreaterEqual, "1"},
+			{"anyface:pip:r", Equal, "2"},
+		},
+		"goblin t:creature": {
+			{"", Equal, "goblin"},
+			{"t", Colon, "creature"},
+		},
+		"t:creature goblin guide": {
+			{"t", Colon, "creature"},
+			{"", Equal, "goblin"},
+			{"", Equal, "guide"},
+		},
+		"goblin !cow": {
+			{"", Equal, "goblin"},
+			{"!name", Equal, "cow"},
+		},
 		"!cow f:c": {
 			{"!name", Equal, "cow"},
 			{"f", Colon, "c"},
@@ -55,15 +78,19 @@ func TestParser(t *testing.T) {
 		// 	{"f", Colon, "c"},
 		// },
 		`cmc<=12 manavalue>=12 cmc>11 cmc<13 name:/sword .f/ name=Excalibur !"Excalibur, Sword of Eden" t:artifact`: Intersection{[]Query{
-			Manavalue{LessEqual, 12},
-			Manavalue{GreaterEqual, 12},
-			Manavalue{Greater, 11},
-			Manavalue{Less, 13},
+			Number{"manavalue", LessEqual, 12},
+			Number{"manavalue", GreaterEqual, 12},
+			Number{"manavalue", Greater, 11},
+			Number{"manavalue", Less, 13},
 			NameRegex{regexp.MustCompile(`(?im)sword .f`)},
 			Name{"Excalibur"},
 			NameExact{"Excalibur, Sword of Eden"},
 			Type{"artifact"},
 		}},
+		"front:pip:g>=1 allfaces:t:creature": Intersection{[]Query{
+			Faces{FrontFace, Devotion{card.Colors{"G"}, GreaterEqual, 1}},
+			Faces{AllFaces, Type{"creature"}},
+		}},
 	}
 	for c, expected := range cases {
 		parsed, err := Parse(c, false)
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"mtgBuilder/card"
 )
@@ -56,22 +58,34 @@ func parseRelationship(inner string) (relationship, error) {
 var ErrInvalidBang = errors.New("invalid '!'")
 
 var fieldAliases = map[string]string{
-	"c":     "color",
-	"id":    "identity",
-	"ci":    "identity",
-	"t":     "type",
-	"o":     "oracle",
-	"fo":    "fulloracle",
-	"kw":    "keyword",
-	"m":     "mana",
-	"mv":    "manavalue",
-	"cmc":   "manavalue",
-	"st":    "set_type",
-	"f":     "format",
-	"legal": "format",
-	"p":     "power",
-	"pow":   "power",
-	"tou":   "toughness",
+	"c":        "color",
+	"id":       "identity",
+	"ci":       "identity",
+	"t":        "type",
+	"o":        "oracle",
+	"fo":       "fulloracle",
+	"kw":       "keyword",
+	"m":        "mana",
+	"mv":       "manavalue",
+	"cmc":      "manavalue",
+	"st":       "set_type",
+	"b":        "block",
+	"f":        "format",
+	"p":        "power",
+	"pow":      "power",
+	"tou":      "toughness",
+	"loy":      "loyalty",
+	"def":      "defense",
+	"a":        "artist",
+	"ft":       "flavor",
+	"wm":       "watermark",
+	"stamp":    "securitystamp",
+	"makes":    "token",
+	"language": "lang",
+	"pname":    "printedname",
+	"ptext":    "printedtext",
+	"ptype":    "printedtype",
+	"ruling":   "rulings",
 }
 
 var ErrInvalidColor = errors.New("invalid color")
@@ -83,6 +97,14 @@ func stripQuotes(s string) (res string, success bool) {
 	return "", false
 }
 
+// unquote returns s without surrounding quotes, if any
+func unquote(s string) string {
+	if stripped, ok := stripQuotes(s); ok {
+		return stripped
+	}
+	return s
+}
+
 func stripSlash(s string) (res string, success bool) {
 	if len(s) >= 2 && s[0] == '/' && s[len(s)-1] == '/' {
 		return s[1 : len(s)-1], true
@@ -174,6 +196,25 @@ func parseType(ineq Inequality) (Query, error) {
 	return Type{val}, nil
 }
 
+func parseTypePart(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	val := ineq.Right
+	if stripped, ok := stripQuotes(ineq.Right); ok {
+		val = stripped
+	}
+	switch ineq.Left {
+	case "supertype":
+		return Supertype{val}, nil
+	case "cardtype":
+		return CardType{val}, nil
+	case "subtype":
+		return Subtype{val}, nil
+	}
+	panic(fmt.Sprintf("invalid type part: %#v", ineq))
+}
+
 func parseOracle(ineq Inequality) (Query, error) {
 	if ineq.Relationship != Equal && ineq.Relationship != Colon {
 		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
@@ -182,13 +223,13 @@ func parseOracle(ineq Inequality) (Query, error) {
 	if stripped, ok := stripQuotes(ineq.Right); ok {
 		val = stripped
 	} else if stripped, ok := stripSlash(ineq.Right); ok {
-		re, err := regexp.Compile("(?im)" + stripped)
+		re, err := regexp.Compile("(?im)" + normalizeSelfReference(stripped))
 		if err != nil {
 			return nil, err
 		}
 		return OracleTextRegex{re}, nil
 	}
-	return OracleText{val}, nil
+	return OracleText{normalizeSelfReference(strings.ToLower(val))}, nil
 }
 
 func parseFullOracle(ineq Inequality) (Query, error) {
@@ -199,13 +240,13 @@ func parseFullOracle(ineq Inequality) (Query, error) {
 	if stripped, ok := stripQuotes(ineq.Right); ok {
 		val = stripped
 	} else if stripped, ok := stripSlash(ineq.Right); ok {
-		re, err := regexp.Compile("(?im)" + stripped)
+		re, err := regexp.Compile("(?im)" + normalizeSelfReference(stripped))
 		if err != nil {
 			return nil, err
 		}
 		return FullOracleTextRegex{re}, nil
 	}
-	return FullOracleText{val}, nil
+	return FullOracleText{normalizeSelfReference(strings.ToLower(val))}, nil
 }
 
 func parseKeyword(ineq Inequality) (Query, error) {
@@ -219,15 +260,30 @@ func parseKeyword(ineq Inequality) (Query, error) {
 	return Type{val}, nil
 }
 
+// parseSet parses set:dom, or compares release order with set>=dom using the release dates of the sets registered
+// with RegisterSets or LoadSets. Release order can't be compared without them
 func parseSet(ineq Inequality) (Query, error) {
+	val := strings.ToLower(unquote(ineq.Right))
+	if ineq.Relationship == Equal || ineq.Relationship == Colon {
+		return Set{val}, nil
+	}
+	s, exists := lookupSet(val)
+	if !exists || s.ReleasedAt == "" {
+		return nil, fmt.Errorf("%w: '%s' has no known release date, comparing release order requires scryfall's sets to be loaded", ErrUnknownSet, val)
+	}
+	return SetRelease{ineq.Relationship, val, s.ReleasedAt}, nil
+}
+
+func parseBlock(ineq Inequality) (Query, error) {
 	if ineq.Relationship != Equal && ineq.Relationship != Colon {
 		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
 	}
-	val := ineq.Right
-	if stripped, ok := stripQuotes(ineq.Right); ok {
-		val = stripped
+	val := unquote(ineq.Right)
+	sets := blockSets(val)
+	if len(sets) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownBlock, val)
 	}
-	return Set{val}, nil
+	return Block{strings.ToLower(val), sets}, nil
 }
 
 func parseSetType(ineq Inequality) (Query, error) {
@@ -241,20 +297,95 @@ func parseSetType(ineq Inequality) (Query, error) {
 	return SetType{val}, nil
 }
 
+var ErrInvalidMana = errors.New("invalid mana")
+
+// parseManaString parses a mana cost given either as braced symbols, ex. {2}{G/U}, or as shorthand, ex. 2gg
+func parseManaString(s string) (card.ManaCost, error) {
+	if strings.Contains(s, "{") {
+		return card.ParseManaCost(s)
+	}
+	var m card.ManaCost
+	runes := []rune(strings.ToUpper(s))
+	for i := 0; i < len(runes); {
+		if unicode.IsDigit(runes[i]) {
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			m = append(m, string(runes[start:i]))
+			continue
+		}
+		if !strings.ContainsRune("WUBRGCXYZS", runes[i]) {
+			return nil, fmt.Errorf("%w: unknown symbol '%c' in %s", ErrInvalidMana, runes[i], s)
+		}
+		m = append(m, string(runes[i]))
+		i++
+	}
+	return m, nil
+}
+
 func parseMana(ineq Inequality) (Query, error) {
-	panic("todo")
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	m, err := parseManaString(unquote(ineq.Right))
+	if err != nil {
+		return nil, err
+	}
+	return Mana{m}, nil
 }
 
-func parseManavalue(ineq Inequality) (Query, error) {
-	val := ineq.Right
-	if stripped, ok := stripQuotes(ineq.Right); ok {
-		val = stripped
+func parsePips(ineq Inequality) (Query, error) {
+	val, err := strconv.Atoi(unquote(ineq.Right))
+	if err != nil {
+		return nil, err
+	}
+	rel := ineq.Relationship
+	if rel == Colon {
+		rel = Equal
+	}
+	return Pips{rel, val}, nil
+}
+
+// parseDevotion parses devotion:bbb style queries. The devotion must be at least the amount of symbols given unless another relationship is used
+func parseDevotion(ineq Inequality) (Query, error) {
+	m, err := parseManaString(unquote(ineq.Right))
+	if err != nil {
+		return nil, err
+	}
+	var colors card.Colors
+	for _, s := range m {
+		symbolColors := card.SymbolColors(s)
+		if len(symbolColors) == 0 {
+			return nil, fmt.Errorf("%w: devotion to colorless symbol %s", ErrInvalidMana, s)
+		}
+		colors.Add(symbolColors)
+	}
+	rel := ineq.Relationship
+	if rel == Colon {
+		rel = GreaterEqual
+	}
+	return Devotion{colors, rel, len(m)}, nil
+}
+
+// parsePip parses pip:g>=2 style queries, counting the symbols of a single color
+func parsePip(ineq Inequality, color string) (Query, error) {
+	colors, err := parseColorString(strings.ToLower(color))
+	if err != nil {
+		return nil, err
+	}
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("%w: pips of colorless", ErrInvalidColor)
 	}
-	f, err := strconv.ParseFloat(val, 32)
+	val, err := strconv.Atoi(unquote(ineq.Right))
 	if err != nil {
 		return nil, err
 	}
-	return Manavalue{ineq.Relationship, float32(f)}, nil
+	rel := ineq.Relationship
+	if rel == Colon {
+		rel = Equal
+	}
+	return Devotion{colors, rel, val}, nil
 }
 
 func parseName(ineq Inequality) (Query, error) {
@@ -289,65 +420,226 @@ func parseFormat(ineq Inequality) (Query, error) {
 	if ineq.Relationship != Equal && ineq.Relationship != Colon {
 		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
 	}
-	val := strings.ToLower(ineq.Right)
-	if stripped, ok := stripQuotes(ineq.Right); ok {
-		val = stripped
-	}
+	val := strings.ToLower(unquote(ineq.Right))
 	if expanded, exists := formatAliases[val]; exists {
 		val = expanded
 	}
-	switch ineq.Left {
-	case "format":
-		return Format{val, "legal"}, nil
-	case "banned":
-		return Format{val, "banned"}, nil
-	case "restricted":
-		return Format{val, "restricted"}, nil
+	format, exists := card.LookupFormat(val)
+	if !exists {
+		var known []string
+		for _, f := range card.Formats() {
+			known = append(known, string(f.Format))
+		}
+		return nil, fmt.Errorf("%w: '%s', expected one of %s", ErrUnknownFormat, val, strings.Join(known, ", "))
 	}
-	panic(fmt.Sprintf("invalid format: %#v", ineq))
+	status, exists := legalityFields[ineq.Left]
+	if !exists {
+		panic(fmt.Sprintf("invalid format: %#v", ineq))
+	}
+	return Format{format.Format, status}, nil
 }
 
-func parsePower(ineq Inequality) (Query, error) {
+func parseOracleID(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
 	val := ineq.Right
 	if stripped, ok := stripQuotes(ineq.Right); ok {
 		val = stripped
 	}
-	var f float32
-	if val != "*" && val != "X" {
-		f64, err := strconv.ParseFloat(val, 32)
+	return OracleID{val}, nil
+}
+
+func parseArtist(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	return Artist{strings.ToLower(unquote(ineq.Right))}, nil
+}
+
+func parseFlavor(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	if stripped, ok := stripSlash(ineq.Right); ok {
+		re, err := regexp.Compile("(?im)" + stripped)
 		if err != nil {
 			return nil, err
 		}
-		f = float32(f64)
+		return FlavorTextRegex{re}, nil
 	}
-	return Power{ineq.Relationship, f}, nil
+	return FlavorText{strings.ToLower(unquote(ineq.Right))}, nil
 }
 
-func parseToughness(ineq Inequality) (Query, error) {
-	val := ineq.Right
-	if stripped, ok := stripQuotes(ineq.Right); ok {
-		val = stripped
+func parseRulings(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
 	}
-	var f float32
-	if val != "*" && val != "X" {
-		f64, err := strconv.ParseFloat(val, 32)
+	if stripped, ok := stripSlash(ineq.Right); ok {
+		re, err := regexp.Compile("(?im)" + stripped)
 		if err != nil {
 			return nil, err
 		}
-		f = float32(f64)
+		return RulingsRegex{re}, nil
 	}
-	return Toughness{ineq.Relationship, f}, nil
+	return Rulings{strings.ToLower(unquote(ineq.Right))}, nil
 }
 
-func parseOracleID(ineq Inequality) (Query, error) {
+// parsePrintField parses fields comparing a printing's attribute against a single value
+func parsePrintField(ineq Inequality) (Query, error) {
 	if ineq.Relationship != Equal && ineq.Relationship != Colon {
 		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
 	}
-	val := ineq.Right
-	if stripped, ok := stripQuotes(ineq.Right); ok {
-		val = stripped
+	val := unquote(ineq.Right)
+	switch ineq.Left {
+	case "watermark":
+		return Watermark{val}, nil
+	case "border":
+		return BorderColor{val}, nil
+	case "frame":
+		return Frame{val}, nil
+	case "frameeffect":
+		return FrameEffect{val}, nil
+	case "securitystamp":
+		return SecurityStamp{val}, nil
+	case "promo":
+		return PromoType{val}, nil
+	}
+	panic(fmt.Sprintf("invalid print field: %#v", ineq))
+}
+
+func parseRelated(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
 	}
-	return OracleID{val}, nil
+	return Related{ineq.Left, strings.ToLower(unquote(ineq.Right))}, nil
+}
+
+func parseLang(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	return Lang{strings.ToLower(unquote(ineq.Right))}, nil
+}
+
+func parsePrinted(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	val := fold(unquote(ineq.Right))
+	switch ineq.Left {
+	case "printedname":
+		return PrintedName{val}, nil
+	case "printedtext":
+		return PrintedText{val}, nil
+	case "printedtype":
+		return PrintedType{val}, nil
+	}
+	panic(fmt.Sprintf("invalid printed field: %#v", ineq))
+}
+
+var ErrInvalidNumber = errors.New("invalid number")
+
+// parseNumeric parses comparisons, ranges such as mv:2..4 and parity such as pow:even for any of numericFields
+func parseNumeric(ineq Inequality, field string) (Query, error) {
+	val := strings.ToLower(unquote(ineq.Right))
+	rel := ineq.Relationship
+	if rel == Colon {
+		rel = Equal
+	}
+
+	if val == "even" || val == "odd" {
+		if rel != Equal {
+			return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+		}
+		return NumberParity{field, val == "even"}, nil
+	}
+
+	if val == "*" || val == "x" {
+		if _, ok := statFields[field]; !ok || rel != Equal {
+			return nil, fmt.Errorf("%w: unable to compare %s with %s", ErrInvalidNumber, ineq.Left, val)
+		}
+		return VariableStat{field}, nil
+	}
+
+	if low, high, found := strings.Cut(val, ".."); found {
+		if rel != Equal {
+			return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+		}
+		minimum, err := parseNumber(low)
+		if err != nil {
+			return nil, err
+		}
+		maximum, err := parseNumber(high)
+		if err != nil {
+			return nil, err
+		}
+		if minimum > maximum {
+			return nil, fmt.Errorf("%w: empty range %s", ErrInvalidNumber, val)
+		}
+		return NumberRange{field, minimum, maximum}, nil
+	}
+
+	f, err := parseNumber(val)
+	if err != nil {
+		return nil, err
+	}
+	return Number{field, rel, f}, nil
+}
+
+// parseNumber parses a number
+func parseNumber(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidNumber, s)
+	}
+	return f, nil
+}
+
+var ErrUnknownGame = errors.New("unknown game")
+
+var games = []string{"paper", "arena", "mtgo"}
+
+func parseGame(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	val := strings.ToLower(unquote(ineq.Right))
+	if !slices.Contains(games, val) {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownGame, val)
+	}
+	switch ineq.Left {
+	case "game":
+		return Game{val}, nil
+	case "in":
+		return Available{val}, nil
+	}
+	panic(fmt.Sprintf("invalid game field: %#v", ineq))
+}
+
+func parseLayout(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Equal && ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	return Layout{unquote(ineq.Right)}, nil
+}
+
+var ErrUnknownProperty = errors.New("unknown property")
+
+// properties are the queries selectable with is:
+var properties = map[string]Query{
+	"digital": Digital{},
+}
+
+func parseIs(ineq Inequality) (Query, error) {
+	if ineq.Relationship != Colon {
+		return nil, fmt.Errorf("%w: unable to compare %s with %+v", ErrInvalidRelationship, ineq.Left, ineq.Relationship)
+	}
+	val := strings.ToLower(unquote(ineq.Right))
+	if q, exists := properties[val]; exists {
+		return q, nil
+	}
+	return nil, fmt.Errorf("%w: 'is:%s'", ErrUnknownProperty, val)
 }
 
 var ErrUnknownField = errors.New("unknown field")
@@ -356,10 +648,26 @@ func parseInequality(ineq Inequality) (Query, error) {
 	if ineq.Relationship == Invalid {
 		panic(ineq)
 	}
+	if prefix, left, found := strings.Cut(ineq.Left, ":"); found {
+		if mode, exists := faceModes[strings.ToLower(prefix)]; exists {
+			ineq.Left = left
+			q, err := parseInequality(ineq)
+			if err != nil {
+				return nil, err
+			}
+			return Faces{mode, q}, nil
+		}
+		if strings.ToLower(prefix) == "pip" {
+			return parsePip(ineq, left)
+		}
+	}
 	field := strings.ToLower(ineq.Left)
 	if expanded, exists := fieldAliases[field]; exists {
 		field = expanded
 	}
+	if _, exists := numericFields[field]; exists {
+		return parseNumeric(ineq, field)
+	}
 	switch field {
 	case "color":
 		return parseColor(ineq)
@@ -367,6 +675,9 @@ func parseInequality(ineq Inequality) (Query, error) {
 		return parseColorIdentity(ineq)
 	case "type":
 		return parseType(ineq)
+	case "supertype", "cardtype", "subtype":
+		ineq.Left = field
+		return parseTypePart(ineq)
 	case "oracle":
 		return parseOracle(ineq)
 	case "fulloracle":
@@ -375,25 +686,49 @@ func parseInequality(ineq Inequality) (Query, error) {
 		return parseKeyword(ineq)
 	case "mana":
 		return parseMana(ineq)
-	case "manavalue":
-		return parseManavalue(ineq)
-	case "name":
+	case "pips":
+		return parsePips(ineq)
+	case "devotion":
+		return parseDevotion(ineq)
+	case "", "name":
 		return parseName(ineq)
 	case "!name":
 		return parseNameExact(ineq)
 	case "set":
 		return parseSet(ineq)
+	case "block":
+		return parseBlock(ineq)
 	case "set_type":
 		return parseSetType(ineq)
-	case "format", "banned", "restricted":
+	case "format", "legal", "banned", "restricted", "notlegal":
 		ineq.Left = field
 		return parseFormat(ineq)
-	case "power":
-		return parsePower(ineq)
-	case "toughness":
-		return parseToughness(ineq)
 	case "oracle_id":
 		return parseOracleID(ineq)
+	case "token", "meld", "combo":
+		ineq.Left = field
+		return parseRelated(ineq)
+	case "lang":
+		return parseLang(ineq)
+	case "printedname", "printedtext", "printedtype":
+		ineq.Left = field
+		return parsePrinted(ineq)
+	case "layout":
+		return parseLayout(ineq)
+	case "game", "in":
+		ineq.Left = field
+		return parseGame(ineq)
+	case "is":
+		return parseIs(ineq)
+	case "artist":
+		return parseArtist(ineq)
+	case "flavor":
+		return parseFlavor(ineq)
+	case "rulings":
+		return parseRulings(ineq)
+	case "watermark", "border", "frame", "frameeffect", "securitystamp", "promo":
+		ineq.Left = field
+		return parsePrintField(ineq)
 	}
 	return nil, fmt.Errorf("%w: '%s'", ErrUnknownField, field)
 }
@@ -403,11 +738,27 @@ var (
 	ErrUnexpectedTokenType  = errors.New("unexpected token")
 )
 
+// isFieldPrefix returns whether ineq is the prefix of a compound field such as front:t:creature or pip:g>=2.
+// Prefixes nest, so the field of a face prefix can itself be compound, ex. front:pip:g>=1
+func isFieldPrefix(ineq Inequality) bool {
+	left := strings.ToLower(ineq.Left)
+	if i := strings.LastIndexByte(left, ':'); i != -1 {
+		left = left[i+1:]
+	}
+	_, exists := faceModes[left]
+	return (exists || left == "pip") && ineq.Relationship == Colon
+}
+
 func groupTokens(runes []rune, tokens []Token) ([]Inequality, error) {
 	var inqualities []Inequality
 	var exact bool
 	var ineq Inequality
 	for _, t := range tokens {
+		if ineq.Left != "" && ineq.Relationship == Invalid && t.Type != Comparison {
+			// a bare word, which has an empty Left
+			inqualities = append(inqualities, Inequality{"", Equal, ineq.Left})
+			ineq = Inequality{}
+		}
 		switch {
 		case ineq.Left != "" && ineq.Relationship != Invalid && t.Type == RHS:
 			ineq.Right = string(t.Get(runes))
@@ -415,14 +766,21 @@ func groupTokens(runes []rune, tokens []Token) ([]Inequality, error) {
 			ineq = Inequality{}
 		case ineq.Left != "" && ineq.Relationship != Invalid:
 			return nil, fmt.Errorf("%w: Expected RHS, found %s", ErrUnexpectedTokenType, "???")
+		case ineq.Left == "" && t.Type == Comparison && len(inqualities) > 0 && isFieldPrefix(inqualities[len(inqualities)-1]):
+			// a compound field such as front:t:creature
+			prefix := inqualities[len(inqualities)-1]
+			inqualities = inqualities[:len(inqualities)-1]
+			relationship, err := parseRelationship(string(t.Get(runes)))
+			if err != nil {
+				return nil, err
+			}
+			ineq = Inequality{prefix.Left + ":" + prefix.Right, relationship, ""}
 		case ineq.Left != "" && t.Type == Comparison:
 			relationship, err := parseRelationship(string(t.Get(runes)))
 			if err != nil {
 				return nil, err
 			}
 			ineq.Relationship = relationship
-		case ineq.Left != "":
-			inqualities = append(inqualities, Inequality{"name", Equal, ineq.Left})
 		case t.Type == Bang && !exact:
 			exact = true
 		case t.Type == Bang:
@@ -436,5 +794,8 @@ func groupTokens(runes []rune, tokens []Token) ([]Inequality, error) {
 			return nil, fmt.Errorf("%w: token %s starting at character %d", ErrUnexpectedTokenType, t.Type.String(), t.Start)
 		}
 	}
+	if ineq.Left != "" && ineq.Relationship == Invalid {
+		inqualities = append(inqualities, Inequality{"", Equal, ineq.Left})
+	}
 	return inqualities, nil
 }
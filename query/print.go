@@ -0,0 +1,125 @@
+package query
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+func artistValues(faces []card.Face) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string { return f.Artist })
+}
+
+func flavorValues(faces []card.Face) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string { return f.FlavorText })
+}
+
+func watermarkValues(faces []card.Face) []fieldValue {
+	return faceValues(faces, func(f *card.Face) *string { return f.Watermark })
+}
+
+func anyValue(values []fieldValue, pred func(string) bool) bool {
+	return slices.ContainsFunc(values, func(v fieldValue) bool { return pred(v.Value) })
+}
+
+type Artist struct {
+	Substr string
+}
+
+func (a Artist) match(artist string) bool {
+	return strings.Contains(strings.ToLower(artist), a.Substr)
+}
+
+func (a Artist) Matches(c *card.Card) bool {
+	return a.matchFaces(c, c.Faces())
+}
+
+func (a Artist) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(artistValues(faces), a.match)
+}
+
+type FlavorText struct {
+	Substr string
+}
+
+func (f FlavorText) match(text string) bool {
+	return strings.Contains(strings.ToLower(text), f.Substr)
+}
+
+func (f FlavorText) Matches(c *card.Card) bool {
+	return f.matchFaces(c, c.Faces())
+}
+
+func (f FlavorText) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(flavorValues(faces), f.match)
+}
+
+type FlavorTextRegex struct {
+	Re *regexp.Regexp
+}
+
+func (f FlavorTextRegex) Matches(c *card.Card) bool {
+	return f.matchFaces(c, c.Faces())
+}
+
+func (f FlavorTextRegex) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(flavorValues(faces), f.Re.MatchString)
+}
+
+type Watermark struct {
+	Name string
+}
+
+func (w Watermark) match(watermark string) bool {
+	return strings.EqualFold(watermark, w.Name)
+}
+
+func (w Watermark) Matches(c *card.Card) bool {
+	return w.matchFaces(c, c.Faces())
+}
+
+func (w Watermark) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(watermarkValues(faces), w.match)
+}
+
+type BorderColor struct {
+	Color string
+}
+
+func (b BorderColor) Matches(c *card.Card) bool {
+	return strings.EqualFold(c.BorderColor, b.Color)
+}
+
+type Frame struct {
+	Name string
+}
+
+func (f Frame) Matches(c *card.Card) bool {
+	return strings.EqualFold(c.Frame, f.Name)
+}
+
+type FrameEffect struct {
+	Name string
+}
+
+func (f FrameEffect) Matches(c *card.Card) bool {
+	return slices.ContainsFunc(c.FrameEffects, func(e string) bool { return strings.EqualFold(e, f.Name) })
+}
+
+type SecurityStamp struct {
+	Name string
+}
+
+func (s SecurityStamp) Matches(c *card.Card) bool {
+	return c.SecurityStamp != nil && strings.EqualFold(*c.SecurityStamp, s.Name)
+}
+
+type PromoType struct {
+	Name string
+}
+
+func (p PromoType) Matches(c *card.Card) bool {
+	return slices.ContainsFunc(c.PromoTypes, func(t string) bool { return strings.EqualFold(t, p.Name) })
+}
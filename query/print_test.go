@@ -0,0 +1,24 @@
+package query_test
+
+import "testing"
+
+func TestPrintFields(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{file: "mdf.json", query: "a:kotaki", expected: true},
+		{file: "mdf.json", query: `artist:"chase stone"`, expected: true},
+		{file: "mdf.json", query: "front:a:kotaki", expected: false},
+		{file: "mdf.json", query: `ft:"dawn of a new age"`, expected: true},
+		{file: "mdf.json", query: `flavor:/^"join me/`, expected: true},
+		{file: "mdf.json", query: "frameeffect:legendary", expected: true},
+		{file: "mdf.json", query: "stamp:oval", expected: true},
+		{file: "nissa.json", query: "wm:desparked", expected: true},
+		{file: "nissa.json", query: "watermark:phyrexian", expected: false},
+		{file: "reversible.json", query: "border:borderless", expected: true},
+		{file: "reversible.json", query: "ft:dolorus", expected: true},
+		{file: "reversible.json", query: `ft:"why rebel"`, expected: true},
+		{file: "split.json", query: "border:borderless", expected: false},
+		{file: "flip.json", query: "frame:2003", expected: true},
+		{file: "flip.json", query: "stamp:oval", expected: false},
+		{file: "flip.json", query: "promo:prerelease", expected: false},
+	})
+}
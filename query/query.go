@@ -8,3 +8,17 @@ import (
 type Query interface {
 	Matches(c *card.Card) bool
 }
+
+// faceMatcher is implemented by queries that read the faces of a card. Queries combining others compute the faces
+// once per card and pass them down, rather than every query computing them
+type faceMatcher interface {
+	matchFaces(c *card.Card, faces []card.Face) bool
+}
+
+// matchFaces matches q against c, passing faces, the faces of c, to queries that read them
+func matchFaces(q Query, c *card.Card, faces []card.Face) bool {
+	if m, ok := q.(faceMatcher); ok {
+		return m.matchFaces(c, faces)
+	}
+	return q.Matches(c)
+}
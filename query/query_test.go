@@ -27,10 +27,7 @@ func TestPower(t *testing.T) {
 		card     card.Card
 		expected bool
 	}{{card.Card{}, false}, {card.Card{Power: &[]string{"1"}[0]}, true}}
-	q, err := query.Parse("power=1", false)
-	if err != nil {
-		t.Fatalf("failed to parse query: %s", err)
-	}
+	q := mustParse(t, "power=1", false)
 	for _, testcase := range cases {
 		logBuf := bytes.Buffer{}
 		slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, &slog.HandlerOptions{Level: slog.LevelDebug})))
@@ -41,3 +38,20 @@ func TestPower(t *testing.T) {
 		}
 	}
 }
+
+func TestType(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{card: &card.Card{TypeLine: "Legendary Creature — Elf Druid"}, query: "t:elf", expected: true},
+		{card: &card.Card{TypeLine: "Creature — Elemental Self"}, query: "t:elf", expected: false},
+		{card: &card.Card{TypeLine: "Artifact"}, query: "t:art", expected: false},
+		{card: &card.Card{TypeLine: "Legendary Planeswalker — Arlinn"}, query: "t:plane", expected: false},
+		{card: &card.Card{TypeLine: "Legendary Creature — Elf Druid"}, query: `t:"legendary creature"`, expected: true},
+		{card: &card.Card{TypeLine: "Legendary Creature — Elf Druid"}, query: "supertype:legendary", expected: true},
+		{card: &card.Card{TypeLine: "Legendary Creature — Elf Druid"}, query: "cardtype:elf", expected: false},
+		{card: &card.Card{TypeLine: "Legendary Creature — Elf Druid"}, query: "subtype:elf", expected: true},
+		{card: &card.Card{
+			TypeLine:  "Legendary Creature — Moonfolk Monk // Legendary Enchantment",
+			CardFaces: []card.CardFace{{TypeLine: &[]string{"Legendary Creature — Moonfolk Monk"}[0]}, {TypeLine: &[]string{"Legendary Enchantment"}[0]}},
+		}, query: "cardtype:enchantment", expected: true},
+	})
+}
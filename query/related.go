@@ -0,0 +1,49 @@
+package query
+
+import (
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// relatedComponents maps the kinds of relation accepted by Related to the components of card.RelatedCard they cover
+var relatedComponents = map[string][]string{
+	"token": {"token"},
+	"meld":  {"meld_part", "meld_result"},
+	"combo": {"combo_piece"},
+}
+
+// Related matches cards with a related object of Kind whose name contains Text or whose type line contains Text as whole words.
+// A card is never related to itself
+type Related struct {
+	Kind string
+	Text string
+}
+
+func (r Related) match(part card.RelatedCard) bool {
+	return strings.Contains(strings.ToLower(part.Name), r.Text) || containsWords(part.TypeLine, r.Text)
+}
+
+func (r Related) parts(c *card.Card) []card.RelatedCard {
+	var parts []card.RelatedCard
+	for _, part := range c.AllParts {
+		if part.Name == c.Name {
+			continue
+		}
+		for _, component := range relatedComponents[r.Kind] {
+			if part.Component == component {
+				parts = append(parts, part)
+			}
+		}
+	}
+	return parts
+}
+
+func (r Related) Matches(c *card.Card) bool {
+	for _, part := range r.parts(c) {
+		if r.match(part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package query_test
+
+import "testing"
+
+func TestRelated(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{file: "double_faced.json", query: "makes:wolf", expected: true},
+		{file: "double_faced.json", query: `token:"token creature"`, expected: true},
+		{file: "double_faced.json", query: "token:zombie", expected: false},
+		{file: "double_faced.json", query: "combo:emblem", expected: true},
+		{file: "double_faced.json", query: "combo:arlinn", expected: true},
+		{file: "mdf.json", query: "token:avatar", expected: true},
+		{file: "mdf.json", query: "combo:extus", expected: false},
+		{file: "nissa.json", query: `combo:"energy reserve"`, expected: true},
+		{file: "nissa.json", query: "combo:nissa", expected: false},
+		{file: "nissa.json", query: "meld:energy", expected: false},
+		{file: "split.json", query: "makes:treasure", expected: false},
+	})
+}
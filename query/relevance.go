@@ -0,0 +1,175 @@
+package query
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"mtgBuilder/card"
+)
+
+// Word matches a bare word anywhere in the name, type line or oracle text of a card.
+// Bare words parse to Word instead of Name when results are ordered by relevance
+type Word struct {
+	Word string
+}
+
+func (w Word) Matches(c *card.Card) bool {
+	return w.matchFaces(c, c.Faces())
+}
+
+func (w Word) matchFaces(c *card.Card, faces []card.Face) bool {
+	for _, f := range relevanceFields {
+		if strings.Contains(fold(f.text(c, faces)), w.Word) {
+			return true
+		}
+	}
+	return false
+}
+
+// relevanceField is a field scored by the relevance order. Matches in fields with a higher Weight score higher
+type relevanceField struct {
+	Name   string
+	Weight float64
+	// text returns the text of the field from a card or its faces
+	text func(c *card.Card, faces []card.Face) string
+}
+
+var relevanceFields = []relevanceField{
+	{"name", 3, func(c *card.Card, _ []card.Face) string { return c.Name }},
+	{"type", 2, func(c *card.Card, _ []card.Face) string { return c.TypeLine }},
+	{"oracle", 1, func(c *card.Card, faces []card.Face) string {
+		var texts []string
+		for _, v := range oracleValues(c, faces, false) {
+			texts = append(texts, v.Value)
+		}
+		return strings.Join(texts, "\n")
+	}},
+}
+
+const (
+	// bm25K1 controls how quickly repeated terms stop increasing the score
+	bm25K1 = 1.2
+	// bm25B controls how much long fields are penalized
+	bm25B = 0.75
+	// exactNameBoost is added to cards whose name is exactly the searched words
+	exactNameBoost = 10
+)
+
+// relevanceTokens splits s into folded words
+func relevanceTokens(s string) []string {
+	return strings.FieldsFunc(fold(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// termFrequency counts the tokens containing term
+func termFrequency(term string, tokens []string) int {
+	n := 0
+	for _, t := range tokens {
+		if strings.Contains(t, term) {
+			n++
+		}
+	}
+	return n
+}
+
+// relevance scores cards against the bare words of a query using BM25 over relevanceFields
+type relevance struct {
+	terms  []string
+	phrase string
+	// docs is the number of cards in the corpus
+	docs int
+	// length is the total number of tokens of each field in the corpus
+	length []int
+	// frequency is the number of cards in the corpus containing each term, per field
+	frequency map[string][]int
+}
+
+func newRelevance(words []string, corpus iter.Seq[*card.Card]) relevance {
+	r := relevance{
+		phrase:    strings.Join(relevanceTokens(strings.Join(words, " ")), " "),
+		length:    make([]int, len(relevanceFields)),
+		frequency: map[string][]int{},
+	}
+	for _, t := range relevanceTokens(r.phrase) {
+		if !slices.Contains(r.terms, t) {
+			r.terms = append(r.terms, t)
+			r.frequency[t] = make([]int, len(relevanceFields))
+		}
+	}
+	if corpus == nil {
+		return r
+	}
+	for c := range corpus {
+		r.docs++
+		faces := c.Faces()
+		for i, f := range relevanceFields {
+			tokens := relevanceTokens(f.text(c, faces))
+			r.length[i] += len(tokens)
+			for _, t := range r.terms {
+				if termFrequency(t, tokens) > 0 {
+					r.frequency[t][i]++
+				}
+			}
+		}
+	}
+	return r
+}
+
+func (r relevance) score(c *card.Card) float64 {
+	var score float64
+	faces := c.Faces()
+	for i, f := range relevanceFields {
+		tokens := relevanceTokens(f.text(c, faces))
+		// without a corpus every card is assumed to be of average length
+		norm := 1.0
+		if r.docs > 0 && r.length[i] > 0 {
+			norm = float64(len(tokens)) * float64(r.docs) / float64(r.length[i])
+		}
+		for _, t := range r.terms {
+			tf := float64(termFrequency(t, tokens))
+			if tf == 0 {
+				continue
+			}
+			df := float64(r.frequency[t][i])
+			idf := math.Log(1 + (float64(r.docs)-df+0.5)/(df+0.5))
+			score += f.Weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*norm))
+		}
+	}
+	if r.phrase != "" && r.isExactName(c, faces) {
+		score += exactNameBoost
+	}
+	return score
+}
+
+func (r relevance) isExactName(c *card.Card, faces []card.Face) bool {
+	if strings.Join(relevanceTokens(c.Name), " ") == r.phrase {
+		return true
+	}
+	return slices.ContainsFunc(faces, func(f card.Face) bool {
+		return strings.Join(relevanceTokens(f.Name), " ") == r.phrase
+	})
+}
+
+// compare orders cards by descending score. Scores are cached by card ID since comparisons revisit the same cards
+func (r relevance) compare() func(a, b *card.Card) int {
+	scores := map[uuid.UUID]float64{}
+	score := func(c *card.Card) float64 {
+		if c.ID == uuid.Nil {
+			return r.score(c)
+		}
+		if s, exists := scores[c.ID]; exists {
+			return s
+		}
+		s := r.score(c)
+		scores[c.ID] = s
+		return s
+	}
+	return func(a, b *card.Card) int {
+		return cmp.Compare(score(b), score(a))
+	}
+}
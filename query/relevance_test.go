@@ -0,0 +1,58 @@
+package query_test
+
+import (
+	"slices"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestRelevance(t *testing.T) {
+	text := func(s string) *string { return &s }
+	cards := []card.Card{
+		{Name: "Lightning Bolt", TypeLine: "Instant", OracleText: text("Lightning Bolt deals 3 damage to any target.")},
+		{Name: "Krenko, Mob Boss", TypeLine: "Legendary Creature — Goblin Warrior", OracleText: text("{T}: Create X 1/1 red Goblin creature tokens, where X is the number of Goblins you control.")},
+		{Name: "Goblin Guide", TypeLine: "Creature — Goblin Scout", OracleText: text("Haste")},
+		{Name: "Goblin Bushwhacker", TypeLine: "Creature — Goblin Warrior", OracleText: text("Kicker {R}")},
+		{Name: "Mogg Fanatic", TypeLine: "Creature — Goblin", OracleText: text("Sacrifice Mogg Fanatic: It deals 1 damage to any target.")},
+		{Name: "Guide of Souls", TypeLine: "Creature — Human Cleric", OracleText: text("Whenever another creature you control enters, you gain 1 life and get {E}.")},
+	}
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{"goblin", []string{"Goblin Bushwhacker", "Goblin Guide"}},
+		{"goblin order:relevance", []string{"Goblin Bushwhacker", "Goblin Guide", "Krenko, Mob Boss", "Mogg Fanatic"}},
+		{"goblin guide order:relevance", []string{"Goblin Guide"}},
+		{"guide order:relevance", []string{"Goblin Guide", "Guide of Souls"}},
+		{`"goblin guide" order:relevance`, []string{"Goblin Guide"}},
+		{"damage order:relevance", []string{"Lightning Bolt", "Mogg Fanatic"}},
+		{"goblin t:legendary order:relevance", []string{"Krenko, Mob Boss"}},
+		{"goblin order:relevance dir:desc", []string{"Mogg Fanatic", "Krenko, Mob Boss", "Goblin Bushwhacker", "Goblin Guide"}},
+	}
+	for _, testcase := range cases {
+		q := mustParse(t, testcase.query, false)
+		var matched []card.Card
+		for _, c := range cards {
+			if q.Matches(&c) {
+				matched = append(matched, c)
+			}
+		}
+		compare := query.CompareIn(q, func(yield func(*card.Card) bool) {
+			for i := range matched {
+				if !yield(&matched[i]) {
+					return
+				}
+			}
+		})
+		slices.SortFunc(matched, func(a, b card.Card) int { return compare(&a, &b) })
+		var got []string
+		for _, c := range matched {
+			got = append(got, c.Name)
+		}
+		if !slices.Equal(got, testcase.expected) {
+			t.Errorf("expected %v, got %v for %s", testcase.expected, got, testcase.query)
+		}
+	}
+}
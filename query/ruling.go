@@ -0,0 +1,37 @@
+package query
+
+import (
+	"regexp"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+func rulingValues(c *card.Card) []fieldValue {
+	values := make([]fieldValue, len(c.Rulings))
+	for i, r := range c.Rulings {
+		values[i] = fieldValue{Value: r.Comment}
+	}
+	return values
+}
+
+// Rulings matches cards with a ruling containing Substr. Cards only have rulings if they were attached when fetched
+type Rulings struct {
+	Substr string
+}
+
+func (r Rulings) match(comment string) bool {
+	return strings.Contains(strings.ToLower(comment), r.Substr)
+}
+
+func (r Rulings) Matches(c *card.Card) bool {
+	return anyValue(rulingValues(c), r.match)
+}
+
+type RulingsRegex struct {
+	Re *regexp.Regexp
+}
+
+func (r RulingsRegex) Matches(c *card.Card) bool {
+	return anyValue(rulingValues(c), r.Re.MatchString)
+}
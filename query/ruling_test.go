@@ -0,0 +1,28 @@
+package query_test
+
+import (
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestRulings(t *testing.T) {
+	c := loadCard(t, "reversible.json")
+	c.Rulings = []card.Ruling{{Comment: "This does not affect creatures that enter the battlefield as a copy of another creature."}}
+	checkMatches(t, false, []matchCase{
+		{card: &c, query: "rulings:copy", expected: true},
+		{card: &c, query: `ruling:"as a COPY"`, expected: true},
+		{card: &c, query: "rulings:/enter.*battlefield/", expected: true},
+		{card: &c, query: "rulings:split", expected: false},
+	})
+
+	without := loadCard(t, "split.json")
+	q := mustParse(t, "rulings:copy", false)
+	if q.Matches(&without) {
+		t.Error("expected a card without rulings not to match")
+	}
+	if e := query.Explain(q, &c); !e.Matched || len(e.Children) != 1 || e.Children[0].Field != "rulings" {
+		t.Errorf("expected the ruling to be explained, got %+v", e)
+	}
+}
@@ -23,6 +23,7 @@ const (
 	Comparison
 	LHS
 	RHS
+	MacroRef
 )
 
 func (t TokenType) String() string {
@@ -35,6 +36,8 @@ func (t TokenType) String() string {
 		return "Left Hand Side"
 	case RHS:
 		return "Right Hand Side"
+	case MacroRef:
+		return "Macro Reference"
 	default:
 		panic(fmt.Sprintf("Invalid TokenType: %d", t))
 	}
@@ -45,7 +48,7 @@ func scan(queryLine string) ([]Token, error) {
 	runes := []rune(queryLine)
 	var i int
 	for i < len(runes) {
-		r := queryLine[i]
+		r := runes[i]
 		switch r {
 		case '!':
 			tokens = append(tokens, Token{i, i + 1, Bang})
@@ -73,6 +76,17 @@ func scan(queryLine string) ([]Token, error) {
 			tokens = append(tokens, Token{i, i + consumed, t})
 			i += consumed
 			continue
+		case '$':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("%w: missing macro name", ErrUnexpectedEndOfInput)
+			}
+			consumed, err := handleUnquotedLiteral(runes[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{i, i + 1 + consumed, MacroRef})
+			i += 1 + consumed
+			continue
 		case ':':
 			tokens = append(tokens, Token{i, i + 1, Comparison})
 		case '=':
@@ -123,15 +137,34 @@ var (
 	ErrUnexpectedEndOfInput = errors.New("unexpected end of input")
 )
 
+func isLiteralRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '~'
+}
+
+// isNumberRune reports whether r may appear in numbers and ranges such as 0.5, 2..4 and *
+func isNumberRune(r rune) bool {
+	return r == '.' || r == '*'
+}
+
+// handleUnquotedLiteral consumes a literal. Any character may appear between braces so mana costs such as {W/U} can be written unquoted
 func handleUnquotedLiteral(runes []rune) (consumed int, err error) {
-	if !unicode.IsLetter(runes[0]) && !unicode.IsDigit(runes[0]) && runes[0] != '_' {
+	if !isLiteralRune(runes[0]) && !isNumberRune(runes[0]) && runes[0] != '{' {
 		return -1, fmt.Errorf("%w: character '%c'", ErrUnexpectedRune, runes[0])
 	}
+	braced := false
 	for i, r := range runes {
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+		switch {
+		case braced:
+			braced = r != '}'
+		case r == '{':
+			braced = true
+		case !isLiteralRune(r) && !isNumberRune(r):
 			return i, nil
 		}
 	}
+	if braced {
+		return -1, fmt.Errorf("%w: missing delimiter }", ErrUnexpectedEndOfInput)
+	}
 	return len(runes), nil
 }
 
@@ -14,6 +14,7 @@ func TestScan(t *testing.T) {
 		`!"woolf"`:                 {{0, 1, Bang}, {1, 8, LHS}},
 		`o`:                        {{0, 1, LHS}},
 		`o:horse`:                  {{0, 1, LHS}, {1, 2, Comparison}, {2, 7, RHS}},
+		`m:{W/U}{2}`:               {{0, 1, LHS}, {1, 2, Comparison}, {2, 10, RHS}},
 		`oracle:/goblin.*warrior/`: {{0, 6, LHS}, {6, 7, Comparison}, {7, 24, RHS}},
 		`f:c oracle:/goblin.*warrior/ !"Wort, the Raidmother"`: {
 			{0, 1, LHS},
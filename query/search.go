@@ -0,0 +1,107 @@
+package query
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"mtgBuilder/card"
+)
+
+// Match is a card matched by Search and its index in the searched cards
+type Match struct {
+	Index int
+	Card  *card.Card
+}
+
+// Search returns the cards matching q, ordered as q requests.
+// Matches point to the yielded cards, so cards must not reuse the card it yields
+func Search(cards iter.Seq2[int, *card.Card], q Query) []Match {
+	var matches []Match
+	for i, c := range cards {
+		if q.Matches(c) {
+			matches = append(matches, Match{i, c})
+		}
+	}
+	compare := CompareIn(q, func(yield func(*card.Card) bool) {
+		for _, m := range matches {
+			if !yield(m.Card) {
+				return
+			}
+		}
+	})
+	slices.SortFunc(matches, func(a, b Match) int { return compare(a.Card, b.Card) })
+	return matches
+}
+
+// MayMatch reports whether a card could match q knowing only summary, a card holding the name, type line, oracle ID,
+// mana value, colors, color identity, set and rarity of the card. False means the card doesn't match,
+// so a search can skip decoding it
+func MayMatch(q Query, summary *card.Card) bool {
+	may, _ := bounds(q, summary)
+	return may
+}
+
+// bounds returns whether a card could match q and whether it must, given its summary.
+// Queries reading fields a summary lacks could match any card and need not match any
+func bounds(q Query, summary *card.Card) (may, must bool) {
+	switch q := q.(type) {
+	case Intersection:
+		may, must = true, true
+		for _, child := range q.Queries {
+			childMay, childMust := bounds(child, summary)
+			may, must = may && childMay, must && childMust
+		}
+		return may, must
+	case Union:
+		for _, child := range q.Queries {
+			childMay, childMust := bounds(child, summary)
+			may, must = may || childMay, must || childMust
+		}
+		return may, must
+	case Negation:
+		may, must = bounds(q.Query, summary)
+		return !must, !may
+	case Ordered:
+		return bounds(q.Query, summary)
+	case Filter:
+		return bounds(q.Exclude, summary)
+	case Set, ColorIdentity, OracleID:
+		m := q.Matches(summary)
+		return m, m
+	case Type, Supertype, CardType, Subtype:
+		// the type line of every face is part of the type line of the card
+		if summary.TypeLine == "" {
+			return true, false
+		}
+		m := q.Matches(summary)
+		return m, m
+	case Number, NumberRange, NumberParity:
+		// a card has its own mana value unless no face mode applies, see numericFields
+		if numberField(q) != "manavalue" || summary.Cmc == nil {
+			return true, false
+		}
+		m := q.Matches(summary)
+		return m, m
+	case Name:
+		// the name of every face is part of the name of the card, which may also match across faces
+		return q.Matches(summary), false
+	case NameExact:
+		name := strings.ToLower(summary.Name)
+		return name == q.Name || slices.Contains(strings.Split(name, " // "), q.Name), name == q.Name
+	}
+	return true, false
+}
+
+// numberField returns the field compared by a numeric query
+func numberField(q Query) string {
+	switch q := q.(type) {
+	case Number:
+		return q.Field
+	case NumberRange:
+		return q.Field
+	case NumberParity:
+		return q.Field
+	}
+	return ""
+}
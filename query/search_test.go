@@ -0,0 +1,81 @@
+package query_test
+
+import (
+	"slices"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestSearch(t *testing.T) {
+	var cards []card.Card
+	for _, file := range []string{"split.json", "nissa.json", "flip.json", "mdf.json"} {
+		cards = append(cards, loadCard(t, file))
+	}
+	all := func(yield func(int, *card.Card) bool) {
+		for i := range cards {
+			if !yield(i, &cards[i]) {
+				return
+			}
+		}
+	}
+
+	q := mustParse(t, "t:creature order:mv dir:desc", false)
+	matches := query.Search(all, q)
+	var got []int
+	for _, m := range matches {
+		if m.Card.Name != cards[m.Index].Name {
+			t.Errorf("match %d is %s, expected %s", m.Index, m.Card.Name, cards[m.Index].Name)
+		}
+		got = append(got, m.Index)
+	}
+	if expected := []int{3, 1, 2}; !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestMayMatch(t *testing.T) {
+	parse := func(q string) query.Query { return mustParse(t, q, false) }
+	not := func(q string) query.Query { return query.Negation{Query: parse(q)} }
+	cases := []struct {
+		file  string
+		query query.Query
+		may   bool
+	}{
+		{"nissa.json", parse("t:sorcery"), false},
+		{"nissa.json", not("t:creature"), false},
+		{"nissa.json", not("t:sorcery"), true},
+		{"nissa.json", query.Union{Queries: []query.Query{parse("set:stx"), parse("set:drc")}}, true},
+		{"nissa.json", query.Union{Queries: []query.Query{parse("set:stx"), parse("mv>4")}}, false},
+		{"nissa.json", parse("o:sorcery"), true},
+		{"nissa.json", not("o:elf"), true},
+		{"nissa.json", query.ColorIdentity{Operator: query.LessEqual, Colors: card.Colors{"G", "U"}}, true},
+		{"nissa.json", query.ColorIdentity{Operator: query.Equal, Colors: card.Colors{"R"}}, false},
+		{"nissa.json", parse("worldsoul t:elf"), true},
+		{"nissa.json", parse("goblin"), false},
+		{"nissa.json", not("worldsoul"), true},
+		{"nissa.json", mustParse(t, "t:elf", true), true},
+		{"split.json", parse("!tear"), true},
+		{"split.json", not(`!"wear // tear"`), false},
+		{"split.json", parse(`"r // t"`), true},
+		{"mdf.json", parse("cardtype:sorcery"), true},
+		{"mdf.json", parse("front:t:sorcery"), true},
+		{"mdf.json", parse("mv=8"), false},
+		{"reversible.json", parse("t:enchantment"), true},
+		{"reversible.json", parse("mv=3"), true},
+	}
+	for _, testcase := range cases {
+		c := loadCard(t, testcase.file)
+		summary := card.Card{Name: c.Name, TypeLine: c.TypeLine, Cmc: c.Cmc, Colors: c.Colors, ColorIdentity: c.ColorIdentity}
+		summary.OracleID, summary.Set, summary.Rarity = c.OracleID, c.Set, c.Rarity
+
+		if got := query.MayMatch(testcase.query, &summary); got != testcase.may {
+			t.Errorf("got %t, expected %t when checking whether %+v may match %s", got, testcase.may, testcase.query, testcase.file)
+		}
+		// a card that matches must never be ruled out by its summary
+		if testcase.query.Matches(&c) && !query.MayMatch(testcase.query, &summary) {
+			t.Errorf("%+v matches %s but its summary rules it out", testcase.query, testcase.file)
+		}
+	}
+}
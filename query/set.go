@@ -1,7 +1,12 @@
 package query
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 
 	"mtgBuilder/card"
 )
@@ -21,3 +26,92 @@ type SetType struct {
 func (s SetType) Matches(c *card.Card) bool {
 	return s.Name == strings.ToLower(c.SetType)
 }
+
+// setCatalog holds the sets known to block: and set release order queries, keyed by lowercased code
+var setCatalog = map[string]card.Set{}
+
+// setsMu guards setCatalog, which may be loaded while queries are parsed and matched
+var setsMu sync.RWMutex
+
+var (
+	ErrUnknownSet   = errors.New("unknown set")
+	ErrUnknownBlock = errors.New("unknown block")
+)
+
+// RegisterSets adds sets to the catalog, replacing sets with the same code
+func RegisterSets(sets []card.Set) {
+	setsMu.Lock()
+	defer setsMu.Unlock()
+	for _, s := range sets {
+		setCatalog[strings.ToLower(s.Code)] = s
+	}
+}
+
+// lookupSet finds a set in the catalog by its code, ignoring case
+func lookupSet(code string) (card.Set, bool) {
+	setsMu.RLock()
+	defer setsMu.RUnlock()
+	s, exists := setCatalog[strings.ToLower(code)]
+	return s, exists
+}
+
+// LoadSets registers the sets in a file saved from scryfall's sets endpoint, see card.DecodeSets
+func LoadSets(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sets, err := card.DecodeSets(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	RegisterSets(sets)
+	return nil
+}
+
+// releasedAt returns the release date of the set of c, or of c itself if the set is not in the catalog
+func releasedAt(c *card.Card) string {
+	if s, exists := lookupSet(c.Set); exists && s.ReleasedAt != "" {
+		return s.ReleasedAt
+	}
+	return c.ReleasedAt
+}
+
+// SetRelease compares the release date of the set of a card with the release date of the set Code, ex. set>=dom
+type SetRelease struct {
+	Relationship relationship
+	Code         string
+	// ReleasedAt is the release date of Code as YYYY-MM-DD, which orders the same as the dates
+	ReleasedAt string
+}
+
+func (s SetRelease) Matches(c *card.Card) bool {
+	released := releasedAt(c)
+	return released != "" && fieldCompare(released, s.Relationship, s.ReleasedAt)
+}
+
+// Block matches cards from any of the Sets of a block
+type Block struct {
+	Name string
+	// Sets are the lowercased codes of the sets in the block
+	Sets []string
+}
+
+func (b Block) Matches(c *card.Card) bool {
+	return slices.Contains(b.Sets, strings.ToLower(c.Set))
+}
+
+// blockSets returns the codes of the sets in the catalog whose block code or block name is name, ignoring case
+func blockSets(name string) []string {
+	setsMu.RLock()
+	defer setsMu.RUnlock()
+	var codes []string
+	for code, s := range setCatalog {
+		if (s.BlockCode != nil && strings.EqualFold(*s.BlockCode, name)) || (s.Block != nil && strings.EqualFold(*s.Block, name)) {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	return codes
+}
@@ -0,0 +1,38 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"mtgBuilder/query"
+)
+
+func TestSetCatalog(t *testing.T) {
+	t.Cleanup(query.ResetSets)
+	if _, err := query.Parse("set>=dom", false); !errors.Is(err, query.ErrUnknownSet) {
+		t.Errorf("expected ErrUnknownSet comparing release order without sets, got %v", err)
+	}
+	if err := query.LoadSets("../card/testdata/sets.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	checkMatches(t, false, []matchCase{
+		{file: "flip.json", query: "block:chk", expected: true},
+		{file: "flip.json", query: `block:"champions of kamigawa"`, expected: true},
+		{file: "split.json", query: "b:chk", expected: false},
+		{file: "split.json", query: "set>=cmr", expected: true},
+		{file: "split.json", query: "set>cmr", expected: false},
+		{file: "split.json", query: "set<stx", expected: true},
+		{file: "mdf.json", query: "set<=pstx", expected: false},
+		{file: "flip.json", query: "set<cmr", expected: true},
+		// drc is not in the catalog, so the card's own release date is used
+		{file: "nissa.json", query: "set>stx", expected: true},
+	})
+
+	if _, err := query.Parse("set>xyz", false); !errors.Is(err, query.ErrUnknownSet) {
+		t.Errorf("expected ErrUnknownSet, got %v", err)
+	}
+	if _, err := query.Parse("block:xyz", false); !errors.Is(err, query.ErrUnknownBlock) {
+		t.Errorf("expected ErrUnknownBlock, got %v", err)
+	}
+}
@@ -3,7 +3,10 @@ package query
 import (
 	"cmp"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
 
 	"mtgBuilder/card"
 )
@@ -25,62 +28,173 @@ func fieldCompare[T cmp.Ordered](a T, rel relationship, b T) bool {
 	panic(fmt.Sprintf("invalid rel %+v", rel))
 }
 
-type Power struct {
-	Relationship relationship
-	Value        float32
+// numberValue is a single value of a numeric field, along with the face it was read from
+type numberValue struct {
+	Face  string
+	Value float64
 }
 
-func (p Power) Matches(c *card.Card) bool {
-	if c.Power != nil {
-		var power float32
-		if f, err := strconv.ParseFloat(*c.Power, 32); err == nil {
-			power = float32(f)
+// numericFields extract the values of each numeric field of a card from the card or its faces, one per face that has the field
+var numericFields = map[string]func(c *card.Card, faces []card.Face) []numberValue{
+	"manavalue": func(c *card.Card, faces []card.Face) []numberValue {
+		// a multifaced card has the combined mana value of its faces unless a single face is being matched
+		if len(faces) > 1 && c.Cmc != nil {
+			return []numberValue{{"", float64(*c.Cmc)}}
+		}
+		var numbers []numberValue
+		for _, f := range faces {
+			if f.Cmc == nil {
+				continue
+			}
+			var name string
+			if len(faces) > 1 {
+				name = f.Name
+			}
+			numbers = append(numbers, numberValue{name, float64(*f.Cmc)})
 		}
-		if fieldCompare(power, p.Relationship, p.Value) {
-			return true
+		return numbers
+	},
+	"power":     statValue("power"),
+	"toughness": statValue("toughness"),
+	"loyalty":   statValue("loyalty"),
+	"defense":   statValue("defense"),
+	"usd":       priceValue(func(p card.Prices) *string { return p.Usd }),
+	"usdfoil":   priceValue(func(p card.Prices) *string { return p.UsdFoil }),
+	"usdetched": priceValue(func(p card.Prices) *string { return p.UsdEtched }),
+	"eur":       priceValue(func(p card.Prices) *string { return p.Eur }),
+	"eurfoil":   priceValue(func(p card.Prices) *string { return p.EurFoil }),
+	"euretched": priceValue(func(p card.Prices) *string { return p.EurEtched }),
+	"tix":       priceValue(func(p card.Prices) *string { return p.Tix }),
+	"edhrec":    rankValue(func(c *card.Card) *int { return c.EdhrecRank }),
+	"penny":     rankValue(func(c *card.Card) *int { return c.PennyRank }),
+}
+
+// statFields read the printed stats of a face
+var statFields = map[string]func(f *card.Face) *string{
+	"power":     func(f *card.Face) *string { return f.Power },
+	"toughness": func(f *card.Face) *string { return f.Toughness },
+	"loyalty":   func(f *card.Face) *string { return f.Loyalty },
+	"defense":   func(f *card.Face) *string { return f.Defense },
+}
+
+// statValue parses a stat of each face. Values like 1+* count as their leading number,
+// values that aren't numeric such as * or X have no value
+func statValue(field string) func(_ *card.Card, faces []card.Face) []numberValue {
+	return func(_ *card.Card, faces []card.Face) []numberValue {
+		var numbers []numberValue
+		for _, v := range faceValues(faces, statFields[field]) {
+			value, _ := strings.CutSuffix(v.Value, "+*")
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				numbers = append(numbers, numberValue{v.Face, f})
+			}
 		}
+		return numbers
 	}
-	for _, face := range c.CardFaces {
-		if face.Power == nil {
-			continue
+}
+
+// priceValue returns the value of a price. Cards without the price have no value
+func priceValue(price func(p card.Prices) *string) func(c *card.Card, _ []card.Face) []numberValue {
+	return func(c *card.Card, _ []card.Face) []numberValue {
+		p := price(c.Prices)
+		if p == nil {
+			return nil
 		}
-		var power float32
-		if f, err := strconv.ParseFloat(*face.Power, 32); err != nil {
-			power = float32(f)
+		f, err := strconv.ParseFloat(*p, 64)
+		if err != nil {
+			return nil
 		}
-		if fieldCompare(power, p.Relationship, p.Value) {
-			return true
+		return []numberValue{{"", f}}
+	}
+}
+
+// rankValue returns the value of a rank. Unranked cards have no value
+func rankValue(rank func(c *card.Card) *int) func(c *card.Card, _ []card.Face) []numberValue {
+	return func(c *card.Card, _ []card.Face) []numberValue {
+		r := rank(c)
+		if r == nil {
+			return nil
 		}
+		return []numberValue{{"", float64(*r)}}
 	}
-	return false
 }
 
-type Toughness struct {
+// anyNumber returns whether any value of field satisfies pred
+func anyNumber(c *card.Card, faces []card.Face, field string, pred func(float64) bool) bool {
+	return slices.ContainsFunc(numericFields[field](c, faces), func(v numberValue) bool { return pred(v.Value) })
+}
+
+// Number compares the values of a numeric field, matching if any face satisfies the comparison
+type Number struct {
+	Field        string
 	Relationship relationship
-	Value        float32
+	Value        float64
 }
 
-func (t Toughness) Matches(c *card.Card) bool {
-	if c.Toughness != nil {
-		var toughness float32
-		if f, err := strconv.ParseFloat(*c.Toughness, 32); err == nil {
-			toughness = float32(f)
-		}
-		if fieldCompare(toughness, t.Relationship, t.Value) {
-			return true
-		}
-	}
-	for _, face := range c.CardFaces {
-		if face.Toughness == nil {
-			continue
-		}
-		var power float32
-		if f, err := strconv.ParseFloat(*face.Toughness, 32); err != nil {
-			power = float32(f)
-		}
-		if fieldCompare(power, t.Relationship, t.Value) {
-			return true
-		}
+func (n Number) match(v float64) bool {
+	return fieldCompare(v, n.Relationship, n.Value)
+}
+
+func (n Number) Matches(c *card.Card) bool {
+	return n.matchFaces(c, c.Faces())
+}
+
+func (n Number) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyNumber(c, faces, n.Field, n.match)
+}
+
+// NumberRange matches numeric fields between Min and Max inclusive
+type NumberRange struct {
+	Field string
+	Min   float64
+	Max   float64
+}
+
+func (r NumberRange) match(v float64) bool {
+	return r.Min <= v && v <= r.Max
+}
+
+func (r NumberRange) Matches(c *card.Card) bool {
+	return r.matchFaces(c, c.Faces())
+}
+
+func (r NumberRange) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyNumber(c, faces, r.Field, r.match)
+}
+
+// NumberParity matches numeric fields that are even, or odd if Even is unset. Fractional values are neither
+type NumberParity struct {
+	Field string
+	Even  bool
+}
+
+func (p NumberParity) match(v float64) bool {
+	if v != math.Trunc(v) {
+		return false
 	}
-	return false
+	return (math.Mod(v, 2) == 0) == p.Even
+}
+
+func (p NumberParity) Matches(c *card.Card) bool {
+	return p.matchFaces(c, c.Faces())
+}
+
+func (p NumberParity) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyNumber(c, faces, p.Field, p.match)
+}
+
+// VariableStat matches stats that depend on the game, such as * or 1+*, which is what pow=* and pow=x search for
+type VariableStat struct {
+	Field string
+}
+
+func (v VariableStat) Matches(c *card.Card) bool {
+	return v.matchFaces(c, c.Faces())
+}
+
+func (v VariableStat) match(s string) bool {
+	return strings.ContainsAny(s, "*xX")
+}
+
+func (v VariableStat) matchFaces(_ *card.Card, faces []card.Face) bool {
+	return anyValue(faceValues(faces, statFields[v.Field]), v.match)
 }
@@ -0,0 +1,89 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"mtgBuilder/card"
+	"mtgBuilder/query"
+)
+
+func TestNumeric(t *testing.T) {
+	checkMatches(t, false, []matchCase{
+		{file: "nissa.json", query: "mv:even", expected: true},
+		{file: "nissa.json", query: "mv:odd", expected: false},
+		{file: "split.json", query: "cmc:odd", expected: true},
+		{file: "nissa.json", query: "mv:2..4", expected: true},
+		{file: "nissa.json", query: "mv:5..6", expected: false},
+		{file: "nissa.json", query: "mv<5", expected: true},
+		{file: "nissa.json", query: "mv>4", expected: false},
+		{file: "nissa.json", query: "pow:odd", expected: true},
+		{file: "nissa.json", query: "pow:even", expected: false},
+		{file: "nissa.json", query: "tou:3", expected: true},
+		{file: "mdf.json", query: "pow:even", expected: true},
+		{file: "mdf.json", query: "tou>=4", expected: true},
+		{file: "mdf.json", query: "front:pow=2", expected: true},
+		{file: "flip.json", query: "pow:0..1", expected: true},
+		{file: "flip.json", query: "pow:*", expected: false},
+		{file: "nissa.json", query: "mv:4.0", expected: true},
+		{file: "split.json", query: "mv=3", expected: true},
+		{file: "split.json", query: "front:mv=2", expected: true},
+		{file: "split.json", query: "front:mv=3", expected: false},
+		{file: "split.json", query: "anyface:mv=1", expected: true},
+		{file: "mdf.json", query: "mv=8", expected: false},
+		{file: "mdf.json", query: "anyface:mv=8", expected: true},
+		{file: "mdf.json", query: "front:mv=4", expected: true},
+		{file: "double_faced.json", query: "allfaces:mv=4", expected: true},
+		{file: "double_faced.json", query: "loy:3", expected: true},
+		{file: "double_faced.json", query: "loyalty:odd", expected: true},
+		{file: "double_faced.json", query: "loy>3", expected: false},
+		{file: "nissa.json", query: "loy:0..10", expected: false},
+		{file: "nissa.json", query: "usd<1", expected: true},
+		{file: "nissa.json", query: "usd:0.2..0.3", expected: true},
+		{file: "reversible.json", query: "usd>=0", expected: false},
+		{file: "split.json", query: "edhrec:800..900", expected: true},
+		{file: "split.json", query: "edhrec:odd", expected: true},
+		{file: "flip.json", query: "edhrec:0..100000", expected: false},
+	})
+}
+
+func TestNonNumericStats(t *testing.T) {
+	// nissa with another power, such as the * of Tarmogoyf or the 0 of Ornithopter
+	withPower := func(power string) *card.Card {
+		c := loadCard(t, "nissa.json")
+		c.Power = &power
+		return &c
+	}
+	star, starPlusOne, x, zero := withPower("*"), withPower("1+*"), withPower("X"), withPower("0")
+	checkMatches(t, false, []matchCase{
+		{card: star, query: "pow:even", expected: false},
+		{card: star, query: "pow=0", expected: false},
+		{card: star, query: "pow<1", expected: false},
+		{card: star, query: "tou:3", expected: true},
+		{card: starPlusOne, query: "pow=1", expected: true},
+		{card: starPlusOne, query: "pow:odd", expected: true},
+		{card: x, query: "pow>=0", expected: false},
+		{card: star, query: "pow=*", expected: true},
+		{card: starPlusOne, query: "pow:*", expected: true},
+		{card: x, query: "pow=x", expected: true},
+		{card: zero, query: "pow=*", expected: false},
+		{card: zero, query: "pow=0", expected: true},
+	})
+}
+
+func TestInvalidNumeric(t *testing.T) {
+	cases := map[string]error{
+		"mv:4..2":  query.ErrInvalidNumber,
+		"mv:two":   query.ErrInvalidNumber,
+		"pow>even": query.ErrInvalidRelationship,
+		"mv<1..3":  query.ErrInvalidRelationship,
+		"mv=*":     query.ErrInvalidNumber,
+		"pow>*":    query.ErrInvalidNumber,
+		"tou:1..x": query.ErrInvalidNumber,
+	}
+	for q, expected := range cases {
+		if _, err := query.Parse(q, false); !errors.Is(err, expected) {
+			t.Errorf("expected %v, got %v for %s", expected, err, q)
+		}
+	}
+}
@@ -1,23 +1,96 @@
 package query
 
 import (
+	"slices"
 	"strings"
 
 	"mtgBuilder/card"
 )
 
+// Type matches cards whose type line contains Text as whole words
 type Type struct {
 	Text string
 }
 
 func (t Type) Matches(c *card.Card) bool {
-	if strings.Contains(strings.ToLower(c.TypeLine), t.Text) {
-		return true
+	return t.matchFaces(c, c.Faces())
+}
+
+func (t Type) matchFaces(c *card.Card, faces []card.Face) bool {
+	// the full type line of a multifaced card matches as well as the type line of each face
+	match := func(typeLine string) bool { return containsWords(typeLine, t.Text) }
+	return match(c.TypeLine) || anyValue(typeLineValues(faces), match)
+}
+
+// containsWords returns whether the words of text appear consecutively in typeLine
+func containsWords(typeLine, text string) bool {
+	words := card.TypeLineWords(typeLine)
+	want := strings.Fields(strings.ToLower(text))
+	if len(want) == 0 {
+		return false
 	}
-	for _, face := range c.CardFaces {
-		if face.TypeLine != nil && strings.Contains(strings.ToLower(c.TypeLine), t.Text) {
+	for i := 0; i+len(want) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(want)], want) {
 			return true
 		}
 	}
 	return false
 }
+
+// anyTypeLine returns whether any part of typeLine satisfies pred once parsed
+func anyTypeLine(typeLine string, pred func(t card.TypeLine) bool) bool {
+	return slices.ContainsFunc(card.ParseTypeLine(typeLine), pred)
+}
+
+// hasType returns whether types contains name, ignoring case
+func hasType(types []string, name string) bool {
+	return slices.ContainsFunc(types, func(t string) bool { return strings.EqualFold(t, name) })
+}
+
+type Supertype struct {
+	Name string
+}
+
+func (s Supertype) Matches(c *card.Card) bool {
+	return s.matchFaces(c, c.Faces())
+}
+
+func (s Supertype) match(typeLine string) bool {
+	return anyTypeLine(typeLine, func(t card.TypeLine) bool { return hasType(t.Supertypes, s.Name) })
+}
+
+func (s Supertype) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(typeLineValues(faces), s.match)
+}
+
+type CardType struct {
+	Name string
+}
+
+func (ct CardType) Matches(c *card.Card) bool {
+	return ct.matchFaces(c, c.Faces())
+}
+
+func (ct CardType) match(typeLine string) bool {
+	return anyTypeLine(typeLine, func(t card.TypeLine) bool { return hasType(t.Types, ct.Name) })
+}
+
+func (ct CardType) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(typeLineValues(faces), ct.match)
+}
+
+type Subtype struct {
+	Name string
+}
+
+func (s Subtype) Matches(c *card.Card) bool {
+	return s.matchFaces(c, c.Faces())
+}
+
+func (s Subtype) match(typeLine string) bool {
+	return anyTypeLine(typeLine, func(t card.TypeLine) bool { return hasType(t.Subtypes, s.Name) })
+}
+
+func (s Subtype) matchFaces(c *card.Card, faces []card.Face) bool {
+	return anyValue(typeLineValues(faces), s.match)
+}
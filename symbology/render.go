@@ -0,0 +1,160 @@
+package symbology
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Format is a way of rendering symbols
+type Format int
+
+const (
+	// Text leaves symbols as plaintext, ex. {G/U}
+	Text Format = iota
+	// Unicode renders symbols as unicode glyphs, ex. Ⓖ/Ⓤ
+	Unicode
+	// ANSI renders symbols as unicode glyphs colored with ANSI escape codes for terminals
+	ANSI
+	// HTML renders symbols as abbr elements with the classes card-symbol and card-symbol-<code>, ex. card-symbol-GU,
+	// where code names the symbol's SVG on scryfall's CDN. Text outside of symbols is escaped
+	HTML
+)
+
+var formatNames = []string{"text", "unicode", "ansi", "html"}
+
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+	return formatNames[f]
+}
+
+var ErrUnknownFormat = errors.New("unknown symbol format")
+
+// ParseFormat returns the format named by String
+func ParseFormat(name string) (Format, error) {
+	for f, n := range formatNames {
+		if strings.EqualFold(n, name) {
+			return Format(f), nil
+		}
+	}
+	return Text, fmt.Errorf("%w: '%s', expected one of %s", ErrUnknownFormat, name, strings.Join(formatNames, ", "))
+}
+
+var symbolRe = regexp.MustCompile(`\{[^{}]+\}`)
+
+// glyphs are the unicode glyphs of each part of a symbol, the parts of {G/U/P} being G, U and P
+var glyphs = func() map[string]string {
+	g := map[string]string{
+		"W": "Ⓦ", "U": "Ⓤ", "B": "Ⓑ", "R": "Ⓡ", "G": "Ⓖ", "C": "Ⓒ",
+		"X": "Ⓧ", "Y": "Ⓨ", "Z": "Ⓩ", "S": "❄", "P": "ϕ",
+		"T": "↷", "Q": "↶", "E": "⚡",
+		"½": "½", "∞": "∞", "HW": "½Ⓦ", "HR": "½Ⓡ",
+		"0": "⓪",
+	}
+	// ① through ⑳ are consecutive code points
+	for n := 1; n <= 20; n++ {
+		g[fmt.Sprint(n)] = string(rune('①' + n - 1))
+	}
+	return g
+}()
+
+// ansiColors are the ANSI foreground colors of each color of mana
+var ansiColors = map[string]string{
+	"W": "93",
+	"U": "94",
+	"B": "35",
+	"R": "91",
+	"G": "92",
+}
+
+// ansiColorless is the ANSI foreground color of generic and colorless mana
+const ansiColorless = "37"
+
+// Render renders every symbol of text known to t in format f. Unknown symbols are left as plaintext
+func (t Table) Render(text string, f Format) string {
+	if f == Text {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range symbolRe.FindAllStringIndex(text, -1) {
+		b.WriteString(t.plain(text[last:loc[0]], f))
+		b.WriteString(t.RenderSymbol(text[loc[0]:loc[1]], f))
+		last = loc[1]
+	}
+	b.WriteString(t.plain(text[last:], f))
+	return b.String()
+}
+
+func (t Table) plain(text string, f Format) string {
+	if f == HTML {
+		return html.EscapeString(text)
+	}
+	return text
+}
+
+// RenderSymbol renders a single symbol, ex. {T}, in format f. Unknown symbols are left as plaintext
+func (t Table) RenderSymbol(symbol string, f Format) string {
+	s, exists := t[symbol]
+	if !exists {
+		return t.plain(symbol, f)
+	}
+
+	switch f {
+	case Unicode, ANSI:
+		return t.glyph(s, f == ANSI)
+	case HTML:
+		return fmt.Sprintf(`<abbr class="card-symbol card-symbol-%s" title="%s">%s</abbr>`,
+			code(s.Symbol), html.EscapeString(s.English), html.EscapeString(s.Symbol))
+	}
+	return symbol
+}
+
+// glyph renders s as unicode glyphs, coloring each part with the colors of its own symbol if colored is set.
+// Symbols without glyphs for every part are left as plaintext
+func (t Table) glyph(s Symbol, colored bool) string {
+	parts := strings.Split(strings.Trim(s.Symbol, "{}"), "/")
+	var b strings.Builder
+	for i, part := range parts {
+		g, exists := glyphs[part]
+		if !exists {
+			return s.Symbol
+		}
+		if i > 0 && part != "P" {
+			b.WriteString("/")
+		}
+		if colored {
+			g = t.colorize(part, g)
+		}
+		b.WriteString(g)
+	}
+	return b.String()
+}
+
+func (t Table) colorize(part, glyph string) string {
+	s, exists := t["{"+part+"}"]
+	if !exists || !s.RepresentsMana {
+		return glyph
+	}
+	color := ansiColorless
+	if len(s.Colors) == 1 {
+		color = ansiColors[s.Colors[0]]
+	}
+	return "\x1b[" + color + "m" + glyph + "\x1b[0m"
+}
+
+// code returns the name of the SVG of symbol on scryfall's CDN, ex. GU for {G/U}
+func code(symbol string) string {
+	r := strings.NewReplacer("{", "", "}", "", "/", "", "½", "HALF", "∞", "INFINITY")
+	return r.Replace(symbol)
+}
+
+// Render renders text with the Default table
+func Render(text string, f Format) string {
+	return Default.Render(text, f)
+}
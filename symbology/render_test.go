@@ -0,0 +1,58 @@
+package symbology_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"mtgBuilder/symbology"
+)
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		text     string
+		format   symbology.Format
+		expected string
+	}{
+		{"{2}{G}{G}", symbology.Text, "{2}{G}{G}"},
+		{"{2}{G}{G}", symbology.Unicode, "②ⒼⒼ"},
+		{"{T}: Add {C}.", symbology.Unicode, "↷: Add Ⓒ."},
+		{"{G/U}{W/P}{B/G/P}", symbology.Unicode, "Ⓖ/ⓊⓌϕⒷ/Ⓖϕ"},
+		{"{CHAOS} {NOTASYMBOL}", symbology.Unicode, "{CHAOS} {NOTASYMBOL}"},
+		{"{R}", symbology.ANSI, "\x1b[91mⓇ\x1b[0m"},
+		{"{2/W}", symbology.ANSI, "\x1b[37m②\x1b[0m/\x1b[93mⓌ\x1b[0m"},
+		{"{T}", symbology.ANSI, "↷"},
+		{"{E} <b>", symbology.HTML, `<abbr class="card-symbol card-symbol-E" title="an energy counter">{E}</abbr> &lt;b&gt;`},
+		{"{G/U}", symbology.HTML, `<abbr class="card-symbol card-symbol-GU" title="one green or blue mana">{G/U}</abbr>`},
+		{"{<}", symbology.HTML, "{&lt;}"},
+	}
+	for _, testcase := range cases {
+		if got := symbology.Render(testcase.text, testcase.format); got != testcase.expected {
+			t.Errorf("rendering %q as %s: expected %q, got %q", testcase.text, testcase.format, testcase.expected, got)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	table, err := symbology.Decode(strings.NewReader(`[{"symbol": "{W}", "english": "one white mana", "represents_mana": true, "colors": ["W"]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Render("{W}{U}", symbology.Unicode); got != "Ⓦ{U}" {
+		t.Errorf("expected only symbols in the table to be rendered, got %q", got)
+	}
+	if len(symbology.Default) == 0 {
+		t.Error("expected the default table to have symbols")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []symbology.Format{symbology.Text, symbology.Unicode, symbology.ANSI, symbology.HTML} {
+		if got, err := symbology.ParseFormat(f.String()); err != nil || got != f {
+			t.Errorf("expected %s to parse, got %s, %v", f, got, err)
+		}
+	}
+	if _, err := symbology.ParseFormat("svg"); !errors.Is(err, symbology.ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
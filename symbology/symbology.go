@@ -0,0 +1,104 @@
+// Package symbology renders the symbols in mana costs and oracle text, such as {2}{G/U} or {T},
+// as terminal glyphs, plain unicode or HTML.
+//
+// Symbols are described by scryfall's symbology data. A copy is embedded as the Default table,
+// a newer copy saved from https://api.scryfall.com/symbology can be read with Decode.
+package symbology
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-json"
+)
+
+// Symbol is a card symbol from scryfall's symbology data
+type Symbol struct {
+	// The plaintext symbol, ex. {W}
+	Symbol string `json:"symbol"`
+
+	// A URI to an SVG image of this symbol on Scryfall’s CDNs.
+	//
+	// This value may be empty
+	SvgURI string `json:"svg_uri,omitempty"`
+
+	// An alternate version of this symbol, if it is possible to write it without curly braces.
+	//
+	// This value may be nil
+	LooseVariant *string `json:"loose_variant,omitempty"`
+
+	// An English snippet that describes this symbol.
+	English string `json:"english"`
+
+	// True if this symbol represents mana.
+	RepresentsMana bool `json:"represents_mana"`
+
+	// True if this symbol appears in a mana cost on any Magic card.
+	AppearsInManaCosts bool `json:"appears_in_mana_costs"`
+
+	// The mana value of this symbol, if it represents mana.
+	//
+	// This value may be nil
+	ManaValue *float32 `json:"mana_value,omitempty"`
+
+	// True if this symbol is a hybrid mana symbol.
+	Hybrid bool `json:"hybrid"`
+
+	// True if the symbol is a Phyrexian mana symbol, you can pay 2 life for it.
+	Phyrexian bool `json:"phyrexian"`
+
+	// True if this symbol is only used on funny cards or Un-cards.
+	Funny bool `json:"funny"`
+
+	// The colors of this symbol, ex. [W U] for {W/U}.
+	Colors []string `json:"colors"`
+}
+
+// Table holds symbols by their plaintext symbol
+type Table map[string]Symbol
+
+// symbolList is the list object returned by scryfall's symbology endpoint
+type symbolList struct {
+	Data []Symbol `json:"data"`
+}
+
+// Decode reads a table from either the list object returned by scryfall's symbology endpoint or a JSON array of symbols
+func Decode(r io.Reader) (Table, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content = bytes.TrimSpace(content)
+
+	var symbols []Symbol
+	if len(content) > 0 && content[0] == '{' {
+		var list symbolList
+		err = json.Unmarshal(content, &list)
+		symbols = list.Data
+	} else {
+		err = json.Unmarshal(content, &symbols)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode symbology: %w", err)
+	}
+
+	t := make(Table, len(symbols))
+	for _, s := range symbols {
+		t[s.Symbol] = s
+	}
+	return t, nil
+}
+
+//go:embed symbology.json
+var defaultSymbology []byte
+
+// Default is the table of the embedded symbology data
+var Default = func() Table {
+	t, err := Decode(bytes.NewReader(defaultSymbology))
+	if err != nil {
+		panic(fmt.Errorf("embedded symbology should always be valid: %w", err))
+	}
+	return t
+}()
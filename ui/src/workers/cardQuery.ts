@@ -63,6 +63,50 @@ export default class CardQuery {
     }
     throw new Error("unimplemented");
   }
+
+  async facetCards(query: string): Promise<Record<string, Record<string, number>>> {
+    // @ts-expect-error untyped value
+    const res = globalThis.GO_cardQuery.facetCards(query)
+    if (res instanceof (Error)) {
+      throw res
+    }
+    if (typeof res == 'string') {
+      return JSON.parse(res);
+    }
+    throw "unreachable";
+  }
+
+  async registerMacro(name: string, fragment: string) {
+    // @ts-expect-error untyped value
+    const res = globalThis.GO_cardQuery.registerMacro(name, fragment)
+    if (res instanceof (Error)) {
+      throw res
+    }
+  }
+
+  async renderSymbols(text: string, format: "text" | "unicode" | "ansi" | "html"): Promise<string> {
+    // @ts-expect-error untyped value
+    const res = globalThis.GO_cardQuery.renderSymbols(text, format)
+    if (res instanceof (Error)) {
+      throw res
+    }
+    if (typeof res == 'string') {
+      return res;
+    }
+    throw "unreachable";
+  }
+
+  async explainCard(query: string, cardIndex: number): Promise<object> {
+    // @ts-expect-error untyped value
+    const res = globalThis.GO_cardQuery.explainCard(query, cardIndex)
+    if (res instanceof (Error)) {
+      throw res
+    }
+    if (typeof res == 'string') {
+      return JSON.parse(res);
+    }
+    throw "unreachable";
+  }
 }
 
 const cardQuery = new CardQuery()
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -13,7 +14,9 @@ import (
 	"github.com/goccy/go-json"
 
 	"mtgBuilder/card"
+	"mtgBuilder/carddb"
 	"mtgBuilder/query"
+	"mtgBuilder/symbology"
 )
 
 func NewError(err error) js.Value {
@@ -59,7 +62,8 @@ func WrapAsync(global js.Value, f func(global js.Value, args []js.Value) (any, e
 	})
 }
 
-var cards []card.Card
+// store holds decoded cards, queries would otherwise decode every card of a database
+var store carddb.CardStore = carddb.NewMemoryStore(nil)
 
 func feedCards(g js.Value, args []js.Value) (any, error) {
 	log.Println("feeding cards")
@@ -73,19 +77,28 @@ func feedCards(g js.Value, args []js.Value) (any, error) {
 	}
 	defer res.Body.Close()
 
-	bytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	start := time.Now()
 
+	// the url may point to a card database or to a JSON array of cards
+	body := bufio.NewReader(res.Body)
 	var c []card.Card
-	if err := json.Unmarshal(bytes, &c); err != nil {
+	if magic, _ := body.Peek(len(carddb.Magic)); carddb.IsDB(magic) {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		db, err := carddb.Open(data)
+		if err != nil {
+			return nil, err
+		}
+		if c, err = db.Cards(); err != nil {
+			return nil, err
+		}
+	} else if c, err = card.DecodeCards(body, nil); err != nil {
 		return nil, err
 	}
 	log.Printf("parsed cards.json in %s", time.Since(start).String())
-	cards = c
+	store = carddb.NewMemoryStore(c)
 	return nil, nil
 }
 
@@ -109,19 +122,47 @@ func queryCards(_ js.Value, args []js.Value) any {
 	if err := CheckArgs(args, []js.Type{js.TypeString}); err != nil {
 		return NewError(err)
 	}
-	query, err := query.Parse(args[0].String(), true)
+	q, err := query.Parse(args[0].String(), true)
 	if err != nil {
 		return NewError(err)
 	}
-	var matches []any
-	for i, c := range cards {
-		if query.Matches(&c) {
-			matches = append(matches, i)
-		}
+	cards, iterErr := carddb.All(store)
+	found := query.Search(cards, q)
+	if err := iterErr(); err != nil {
+		return NewError(err)
+	}
+	matches := make([]any, len(found))
+	for i, m := range found {
+		matches[i] = m.Index
 	}
 	return matches
 }
 
+func facetCards(_ js.Value, args []js.Value) any {
+	if err := CheckArgs(args, []js.Type{js.TypeString}); err != nil {
+		return NewError(err)
+	}
+	q, err := query.Parse(args[0].String(), true)
+	if err != nil {
+		return NewError(err)
+	}
+	facets := query.NewFacets()
+	err = store.Iterate(func(_ int, c *card.Card) error {
+		if q.Matches(c) {
+			facets.Add(c)
+		}
+		return nil
+	})
+	if err != nil {
+		return NewError(err)
+	}
+	bytes, err := json.Marshal(facets)
+	if err != nil {
+		return NewError(err)
+	}
+	return string(bytes)
+}
+
 var ErrIndexOutOfBounds = errors.New("index out of bounds")
 
 func getCard(_ js.Value, args []js.Value) any {
@@ -129,10 +170,13 @@ func getCard(_ js.Value, args []js.Value) any {
 		return NewError(err)
 	}
 	i := args[0].Int()
-	if len(cards) < i {
-		return NewError(fmt.Errorf("%w: %d/%d", ErrIndexOutOfBounds, i, len(cards)))
+	if i < 0 || store.Len() <= i {
+		return NewError(fmt.Errorf("%w: %d/%d", ErrIndexOutOfBounds, i, store.Len()))
+	}
+	c, err := store.Get(i)
+	if err != nil {
+		return NewError(err)
 	}
-	c := cards[i]
 	bytes, err := json.Marshal(c)
 	if err != nil {
 		return NewError(err)
@@ -140,15 +184,64 @@ func getCard(_ js.Value, args []js.Value) any {
 	return string(bytes)
 }
 
+func registerMacro(_ js.Value, args []js.Value) any {
+	if err := CheckArgs(args, []js.Type{js.TypeString, js.TypeString}); err != nil {
+		return NewError(err)
+	}
+	if err := query.RegisterMacro(args[0].String(), args[1].String(), "web ui"); err != nil {
+		return NewError(err)
+	}
+	return nil
+}
+
+func explainCard(_ js.Value, args []js.Value) any {
+	if err := CheckArgs(args, []js.Type{js.TypeString, js.TypeNumber}); err != nil {
+		return NewError(err)
+	}
+	q, err := query.Parse(args[0].String(), true)
+	if err != nil {
+		return NewError(err)
+	}
+	i := args[1].Int()
+	if i < 0 || store.Len() <= i {
+		return NewError(fmt.Errorf("%w: %d/%d", ErrIndexOutOfBounds, i, store.Len()))
+	}
+	c, err := store.Get(i)
+	if err != nil {
+		return NewError(err)
+	}
+	bytes, err := json.Marshal(query.Explain(q, c))
+	if err != nil {
+		return NewError(err)
+	}
+	return string(bytes)
+}
+
+// renderSymbols renders the mana symbols of a mana cost or oracle text in a symbology format, ex. html
+func renderSymbols(_ js.Value, args []js.Value) any {
+	if err := CheckArgs(args, []js.Type{js.TypeString, js.TypeString}); err != nil {
+		return NewError(err)
+	}
+	f, err := symbology.ParseFormat(args[1].String())
+	if err != nil {
+		return NewError(err)
+	}
+	return symbology.Render(args[0].String(), f)
+}
+
 const exportName = "GO_cardQuery"
 
 func main() {
 	g := js.Global()
 	exports := map[string]any{
-		"feedCards":  WrapAsync(g, feedCards),
-		"parseQuery": js.FuncOf(parseQuery),
-		"queryCards": js.FuncOf(queryCards),
-		"getCard":    js.FuncOf(getCard),
+		"feedCards":     WrapAsync(g, feedCards),
+		"parseQuery":    js.FuncOf(parseQuery),
+		"queryCards":    js.FuncOf(queryCards),
+		"getCard":       js.FuncOf(getCard),
+		"explainCard":   js.FuncOf(explainCard),
+		"facetCards":    js.FuncOf(facetCards),
+		"registerMacro": js.FuncOf(registerMacro),
+		"renderSymbols": js.FuncOf(renderSymbols),
 	}
 	g.Set(exportName, exports)
 	log.Printf("exported:\n%+v", exports)